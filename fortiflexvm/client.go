@@ -13,6 +13,11 @@ import (
 type FortiClient struct {
 	Client        *fortisdk.FortiSDKClient
 	ImportOptions *schema.Set // Only used in terraform import
+
+	PointsBudgets     []PointsBudget
+	BudgetEnforcement string // "block" or "warn"
+	budgetState       pointsBudgetState
+	configs           *configCache
 }
 
 // providerConfigure creates a FortiClient Object with the authentication information.
@@ -25,9 +30,25 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	points_budgets, err := expandPointsBudgets(d.Get("points_budget"))
+	if err != nil {
+		return nil, err
+	}
+	budget_enforcement := d.Get("budget_enforcement").(string)
+	if budget_enforcement == "" {
+		budget_enforcement = "block"
+	}
 	return &FortiClient{
-		Client:        client,
-		ImportOptions: d.Get("import_options").(*schema.Set),
+		Client:            client,
+		ImportOptions:     d.Get("import_options").(*schema.Set),
+		PointsBudgets:     points_budgets,
+		BudgetEnforcement: budget_enforcement,
+		budgetState: pointsBudgetState{
+			added:    make(map[int]float64),
+			reserved: make(map[int]float64),
+			serials:  make(map[string]int),
+		},
+		configs: newConfigCache(client),
 	}, nil
 }
 
@@ -2,11 +2,15 @@ package fortiflexvm
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	fortisdk "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
 )
 
 var PRODUCT_TYPES = []string{"fgt_vm_bundle", "fmg_vm", "fwb_vm", "fgt_vm_lcs", "fc_ems_op", "faz_vm",
@@ -83,6 +87,18 @@ func convProductTypeName2Id(p_type string) int {
 	}
 }
 
+// ProductTypeId returns the ID of a product type name, e.g. 1 for "FGT_VM_Bundle".
+// It returns 0 for an unknown name.
+func ProductTypeId(p_type string) int {
+	return convProductTypeName2Id(p_type)
+}
+
+// ProductTypeName returns the name of a product type ID, e.g. "FGT_VM_Bundle" for 1.
+// It returns "" for an unknown ID.
+func ProductTypeName(p_id int) string {
+	return convProductTypeId2Name(p_id)
+}
+
 func convProductTypeId2Name(p_id int) string {
 	switch p_id {
 	case 1:
@@ -138,6 +154,13 @@ func convProductTypeId2Name(p_id int) string {
 	}
 }
 
+// ConfigParameterName returns the product type block, the argument name and the
+// data type of a configuration parameter ID, e.g. "fgt_vm_bundle", "cpu_size", "string" for 1.
+// The names are "" for an unknown ID.
+func ConfigParameterName(p_id int) (string, string, string) {
+	return convConfParsId2NameList(p_id)
+}
+
 func convConfParsId2NameList(p_id int) (string, string, string) {
 	switch p_id {
 	case 1:
@@ -687,6 +710,40 @@ func checkInputValidInt(parameter_name string, lower_bound int, upper_bound int)
 	}
 }
 
+func checkInputValidRegex(parameter_name string) func(interface{}, cty.Path) diag.Diagnostics {
+	return func(v interface{}, p cty.Path) diag.Diagnostics {
+		value := v.(string)
+		var diags diag.Diagnostics
+		if _, err := regexp.Compile(value); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Invalid value of parameter: %v", parameter_name),
+				Detail:   fmt.Sprintf("Invalid %v value: %v\n%v", parameter_name, value, err),
+			})
+		}
+		return diags
+	}
+}
+
+func tryParseISO8601(timeStr string) (time.Time, error) {
+	var layouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02T15",
+		"2006-01-02",
+		"2006-01-02T15:04:05.999999999",
+	}
+
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, timeStr)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupport time format: %s", timeStr)
+}
+
 func splitID(resource_id string) (string, string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	split_parts := strings.Split(resource_id, ".")
@@ -772,11 +829,153 @@ func findConfigFromList(config_list map[string]interface{}, config_id int) (map[
 	return nil, fmt.Errorf("target config %v not exist", config_id)
 }
 
-func changeVMStatus(serial_number string, action string, m interface{}) (map[string]interface{}, error) {
+// changeVMStatus sends 'stop' or 'reactivate' request for one entitlement.
+// The points budget is checked before reactivating it, its warnings are returned.
+func changeVMStatus(serial_number string, config_id int, action string, m interface{}) (map[string]interface{}, []string, error) {
 	c := m.(*FortiClient).Client
 
+	var warnings []string
+	if action == "reactivate" {
+		var err error
+		warnings, err = m.(*FortiClient).CheckPointsBudget(config_id, 1)
+		if err != nil {
+			return nil, warnings, err
+		}
+	}
+
 	obj := make(map[string]interface{})
 	obj["serialNumber"] = serial_number
 	target_entitlement, err := c.UpdateVmUpdateStatus(&obj, action)
-	return target_entitlement, err
+	if err != nil && action == "reactivate" {
+		m.(*FortiClient).ReleasePointsBudget(config_id, 1)
+	}
+	return target_entitlement, warnings, err
+}
+
+// configCache keeps the programs and their configurations read from FortiFlex,
+// so they are read once per provider instance. It is used by the points budget
+// and by the lookups of the program end date and of the product type.
+type configCache struct {
+	sync.Mutex
+	client         *fortisdk.FortiSDKClient
+	programs       []map[string]interface{}
+	programConfigs map[string][]map[string]interface{}
+}
+
+func newConfigCache(client *fortisdk.FortiSDKClient) *configCache {
+	return &configCache{
+		client:         client,
+		programConfigs: make(map[string][]map[string]interface{}),
+	}
+}
+
+// findConfig finds the configuration in the programs 'program_serial_numbers'
+// first, then in the cached programs, then in all the programs. The configuration
+// has a 'programSerialNumber' key.
+func (cache *configCache) findConfig(config_id int, program_serial_numbers []string) (map[string]interface{}, error) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	program_serial_numbers = append([]string{}, program_serial_numbers...)
+	for program_serial_number := range cache.programConfigs {
+		if !contains(program_serial_numbers, program_serial_number) {
+			program_serial_numbers = append(program_serial_numbers, program_serial_number)
+		}
+	}
+	if config, err := cache.findConfigInPrograms(config_id, program_serial_numbers); config != nil || err != nil {
+		return config, err
+	}
+
+	programs, err := cache.readPrograms()
+	if err != nil {
+		return nil, err
+	}
+	program_serial_numbers = make([]string, 0, len(programs))
+	for _, program := range programs {
+		program_serial_numbers = append(program_serial_numbers, fmt.Sprintf("%v", program["serialNumber"]))
+	}
+	if config, err := cache.findConfigInPrograms(config_id, program_serial_numbers); config != nil || err != nil {
+		return config, err
+	}
+	return nil, fmt.Errorf("configuration %v not found in the programs", config_id)
+}
+
+// findProgram returns the program with the serial number.
+func (cache *configCache) findProgram(program_serial_number string) (map[string]interface{}, error) {
+	cache.Lock()
+	defer cache.Unlock()
+
+	programs, err := cache.readPrograms()
+	if err != nil {
+		return nil, err
+	}
+	for _, program := range programs {
+		if fmt.Sprintf("%v", program["serialNumber"]) == program_serial_number {
+			return program, nil
+		}
+	}
+	return nil, fmt.Errorf("program %v not found", program_serial_number)
+}
+
+// configs returns the configurations of the program.
+func (cache *configCache) configs(program_serial_number string) ([]map[string]interface{}, error) {
+	cache.Lock()
+	defer cache.Unlock()
+	return cache.readProgramConfigs(program_serial_number)
+}
+
+func (cache *configCache) findConfigInPrograms(config_id int, program_serial_numbers []string) (map[string]interface{}, error) {
+	for _, program_serial_number := range program_serial_numbers {
+		configs, err := cache.readProgramConfigs(program_serial_number)
+		if err != nil {
+			return nil, err
+		}
+		for _, config := range configs {
+			if fmt.Sprintf("%v", config["id"]) == fmt.Sprintf("%v", config_id) {
+				return config, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (cache *configCache) readPrograms() ([]map[string]interface{}, error) {
+	if cache.programs != nil {
+		return cache.programs, nil
+	}
+	o, err := cache.client.ReadProgramsList(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error reading programs: %v", err)
+	}
+	programs := make([]map[string]interface{}, 0)
+	program_list, _ := o["programs"].([]interface{})
+	for _, program := range program_list {
+		if program_map, ok := program.(map[string]interface{}); ok {
+			programs = append(programs, program_map)
+		}
+	}
+	cache.programs = programs
+	return programs, nil
+}
+
+func (cache *configCache) readProgramConfigs(program_serial_number string) ([]map[string]interface{}, error) {
+	if configs, ok := cache.programConfigs[program_serial_number]; ok {
+		return configs, nil
+	}
+	request_obj := make(map[string]interface{})
+	request_obj["programSerialNumber"] = program_serial_number
+	o, err := cache.client.ReadConfigsList(&request_obj)
+	if err != nil {
+		return nil, fmt.Errorf("error reading configurations of program %v: %v", program_serial_number, err)
+	}
+	configs := make([]map[string]interface{}, 0)
+	config_list, _ := o["configs"].([]interface{})
+	for _, config := range config_list {
+		if config_map, ok := config.(map[string]interface{}); ok {
+			config_map["programSerialNumber"] = program_serial_number
+			configs = append(configs, config_map)
+		}
+	}
+	cache.programConfigs[program_serial_number] = configs
+	return configs, nil
 }
@@ -0,0 +1,168 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Get one configuration by its ID, or by its name in a program.
+
+package fortiflexvm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceConfig() *schema.Resource {
+	config_schema := map[string]*schema.Schema{
+		"account_id": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"config_id": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"program_serial_number": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"name": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"product_type": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"status": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	// The product blocks are the same as fortiflexvm_config
+	resource_schema := resourceConfig().Schema
+	for _, type_name := range PRODUCT_TYPES {
+		config_schema[type_name] = computedSchema(resource_schema[type_name])
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceConfigRead,
+		Schema: config_schema,
+	}
+}
+
+func dataSourceConfigRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*FortiClient).Client
+
+	// Prepare data
+	config_id := d.Get("config_id").(int)
+	name := d.Get("name").(string)
+	program_serial_number := d.Get("program_serial_number").(string)
+	if (config_id == 0) == (name == "") {
+		return fmt.Errorf("either config_id or name is required")
+	}
+	if name != "" && program_serial_number == "" {
+		return fmt.Errorf("program_serial_number is required to find a configuration by name")
+	}
+	program_serial_numbers := []string{program_serial_number}
+	if program_serial_number == "" {
+		// Search the configuration in all the programs
+		o, err := c.ReadProgramsList(nil)
+		if err != nil {
+			return fmt.Errorf("error describing ProgramsList: %v", err)
+		}
+		program_serial_numbers = make([]string, 0)
+		program_list, _ := o["programs"].([]interface{})
+		for _, program := range program_list {
+			if program_map, ok := program.(map[string]interface{}); ok {
+				program_serial_numbers = append(program_serial_numbers, fmt.Sprintf("%v", program_map["serialNumber"]))
+			}
+		}
+	}
+
+	// Send request
+	var target_config map[string]interface{}
+	for _, psn := range program_serial_numbers {
+		request_obj := make(map[string]interface{})
+		request_obj["programSerialNumber"] = psn
+		if v, ok := d.GetOk("account_id"); ok {
+			request_obj["accountId"] = v
+		}
+		o, err := c.ReadConfigsList(&request_obj)
+		if err != nil {
+			return fmt.Errorf("error describing ConfigsList of program %v: %v", psn, err)
+		}
+		if config_id != 0 {
+			if target_config, err = findConfigFromList(o, config_id); err == nil {
+				target_config["programSerialNumber"] = psn
+				break
+			}
+			continue
+		}
+		target_config, err = findConfigByName(o, name)
+		if err != nil {
+			return fmt.Errorf("error finding configuration in program %v: %v", psn, err)
+		}
+	}
+	if target_config == nil {
+		if config_id != 0 {
+			return fmt.Errorf("configuration %v not found", config_id)
+		}
+		return fmt.Errorf("configuration %q not found in program %v", name, program_serial_number)
+	}
+
+	// Update status
+	if _, ok := target_config["programSerialNumber"]; !ok {
+		target_config["programSerialNumber"] = program_serial_number
+	}
+	if err := refreshObjectConfigAttributes(d, target_config); err != nil {
+		return fmt.Errorf("error describing Config from API: %v", err)
+	}
+	d.SetId(fmt.Sprintf("%v", target_config["id"]))
+
+	return nil
+}
+
+// findConfigByName returns the only configuration named 'name' in the list,
+// nil if there is none, and an error if there are several.
+func findConfigByName(config_list map[string]interface{}, name string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	match_ids := make([]string, 0)
+	if conf_list, ok := config_list["configs"].([]interface{}); ok {
+		for _, data_conf := range conf_list {
+			if conf, ok := data_conf.(map[string]interface{}); ok && fmt.Sprintf("%v", conf["name"]) == name {
+				result = conf
+				match_ids = append(match_ids, fmt.Sprintf("%v", conf["id"]))
+			}
+		}
+	}
+	if len(match_ids) > 1 {
+		return nil, fmt.Errorf("%v configurations are named %q: %v, use config_id instead", len(match_ids), name, match_ids)
+	}
+	return result, nil
+}
+
+// computedSchema converts an argument of a resource to an attribute of a data source.
+func computedSchema(s *schema.Schema) *schema.Schema {
+	result := &schema.Schema{
+		Type:        s.Type,
+		Computed:    true,
+		Sensitive:   s.Sensitive,
+		Description: s.Description,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		nested := make(map[string]*schema.Schema)
+		for k, v := range elem.Schema {
+			nested[k] = computedSchema(v)
+		}
+		result.Elem = &schema.Resource{Schema: nested}
+	case *schema.Schema:
+		result.Elem = &schema.Schema{Type: elem.Type}
+	}
+	return result
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Estimate the points used by the entitlements of a configuration.
+
+package fortiflexvm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	forticlient "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
+)
+
+// DAYS_PER_MONTH is used to convert daily points to monthly points.
+const DAYS_PER_MONTH = 30
+
+func dataSourceConfigCostEstimate() *schema.Resource {
+	config_schema := resourceConfig().Schema
+	estimate_schema := map[string]*schema.Schema{
+		"product_type": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"quantity": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  1,
+		},
+		"reference_config_id": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"account_id": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"reference_days": &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  7,
+		},
+		"daily_points": &schema.Schema{
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		"monthly_points": &schema.Schema{
+			Type:     schema.TypeFloat,
+			Computed: true,
+		},
+		"pricing_version": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"source": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	// Accept the same product blocks as fortiflexvm_config
+	for _, type_name := range PRODUCT_TYPES {
+		estimate_schema[type_name] = config_schema[type_name]
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceConfigCostEstimateRead,
+		Schema: estimate_schema,
+	}
+}
+
+func dataSourceConfigCostEstimateRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*FortiClient).Client
+
+	product_type := d.Get("product_type").(string)
+	quantity := d.Get("quantity").(int)
+	table, err := forticlient.EmbeddedPricingTable()
+	if err != nil {
+		return err
+	}
+
+	daily_points := 0.0
+	source := ""
+	if v, ok := d.GetOk("reference_config_id"); ok {
+		// Use the points really consumed by the entitlements of this configuration
+		points, entitlement_num, err := c.ReadAverageDailyPoints(v.(int), d.Get("account_id").(int), d.Get("reference_days").(int))
+		if err != nil {
+			return fmt.Errorf("error reading points of configuration %v: %v", v, err)
+		}
+		if entitlement_num > 0 {
+			daily_points = points
+			source = "api"
+		}
+	}
+	if source == "" {
+		parameters, err := configCostParameters(d, product_type)
+		if err != nil {
+			return err
+		}
+		daily_points, err = table.EstimateDailyPoints(product_type, parameters)
+		if err != nil {
+			return fmt.Errorf("error estimating points: %v", err)
+		}
+		source = "table"
+	}
+
+	daily_points = daily_points * float64(quantity)
+	d.Set("daily_points", daily_points)
+	d.Set("monthly_points", daily_points*DAYS_PER_MONTH)
+	d.Set("pricing_version", table.Version)
+	d.Set("source", source)
+	d.SetId(fmt.Sprintf("%v.%v.%v", product_type, quantity, source))
+
+	return nil
+}
+
+// configCostParameters returns the parameters in the product block of product_type.
+func configCostParameters(d *schema.ResourceData, product_type string) (map[string]interface{}, error) {
+	type_name := strings.ToLower(product_type)
+	if !contains(PRODUCT_TYPES, type_name) {
+		return nil, fmt.Errorf("unknown product_type: %v", product_type)
+	}
+	blocks, ok := d.Get(type_name).([]interface{})
+	if !ok || len(blocks) == 0 || blocks[0] == nil {
+		return nil, fmt.Errorf("%v block is required to estimate the points of %v", type_name, product_type)
+	}
+	return blocks[0].(map[string]interface{}), nil
+}
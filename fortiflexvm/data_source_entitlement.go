@@ -0,0 +1,137 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Get one entitlement by its serial number.
+
+package fortiflexvm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	fortisdk "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
+)
+
+func dataSourceEntitlement() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEntitlementRead,
+		Schema: map[string]*schema.Schema{
+			"serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"account_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"program_serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"start_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"end_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"token": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"token_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceEntitlementRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*FortiClient).Client
+
+	// Prepare data
+	serial_number := d.Get("serial_number").(string)
+	request_obj := make(map[string]interface{})
+	request_obj["serialNumber"] = serial_number
+	if v, ok := d.GetOk("config_id"); ok {
+		request_obj["configId"] = v
+	} else if v, ok := d.GetOk("program_serial_number"); ok {
+		request_obj["programSerialNumber"] = v
+		account_id, err := programAccountID(c, v.(string), d.Get("account_id").(int))
+		if err != nil {
+			return err
+		}
+		request_obj["accountId"] = account_id
+	} else {
+		return fmt.Errorf("either config_id or program_serial_number is required")
+	}
+
+	// Send request
+	o, err := c.ReadEntitlementsList(&request_obj)
+	if err != nil {
+		return fmt.Errorf("error describing EntitlementsList: %v", err)
+	}
+	target_entitlement, err := findEntitlementFromList(o, serial_number)
+	if err != nil {
+		return fmt.Errorf("error finding entitlement: %v", err)
+	}
+	match_num := 0
+	entitlement_list, _ := o["entitlements"].([]interface{})
+	for _, item := range entitlement_list {
+		if ent, ok := item.(map[string]interface{}); ok && fmt.Sprintf("%v", ent["serialNumber"]) == serial_number {
+			match_num += 1
+		}
+	}
+	if match_num > 1 {
+		return fmt.Errorf("%v entitlements have the serial number %v, set config_id to select one", match_num, serial_number)
+	}
+
+	// Update status
+	d.Set("config_id", target_entitlement["configId"])
+	d.Set("account_id", target_entitlement["accountId"])
+	d.Set("description", target_entitlement["description"])
+	d.Set("start_date", target_entitlement["startDate"])
+	d.Set("end_date", target_entitlement["endDate"])
+	d.Set("status", target_entitlement["status"])
+	d.Set("token", target_entitlement["token"])
+	d.Set("token_status", target_entitlement["tokenStatus"])
+	d.SetId(fmt.Sprintf("%v.%v", serial_number, target_entitlement["configId"]))
+
+	return nil
+}
+
+// programAccountID returns account_id, or the account ID of the program if it is 0.
+func programAccountID(c *fortisdk.FortiSDKClient, program_serial_number string, account_id int) (int, error) {
+	if account_id != 0 {
+		return account_id, nil
+	}
+	o, err := c.ReadProgramsList(nil)
+	if err != nil {
+		return 0, fmt.Errorf("error describing ProgramsList: %v", err)
+	}
+	program_list, _ := o["programs"].([]interface{})
+	for _, program := range program_list {
+		if program_map, ok := program.(map[string]interface{}); ok && fmt.Sprintf("%v", program_map["serialNumber"]) == program_serial_number {
+			return int(int64FromValue(program_map["accountId"])), nil
+		}
+	}
+	return 0, fmt.Errorf("program %v not found", program_serial_number)
+}
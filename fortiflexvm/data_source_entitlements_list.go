@@ -8,7 +8,10 @@ package fortiflexvm
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -45,6 +48,70 @@ func dataSourceEntitlementsList() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"token_storage": tokenStorageSchema(),
+			"pgp_key":       pgpKeySchema(),
+			"filter": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"attribute": &schema.Schema{
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: checkInputValidString("attribute", ENTITLEMENT_LIST_ATTRIBUTES),
+						},
+						"operator": &schema.Schema{
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: checkInputValidString("operator", []string{"eq", "ne", "regex", "prefix", "in", "before", "after"}),
+						},
+						"values": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"sort_by": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: checkInputValidString("sort_by", ENTITLEMENT_LIST_ATTRIBUTES),
+			},
+			"sort_order": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "asc",
+				ValidateDiagFunc: checkInputValidString("sort_order", []string{"asc", "desc"}),
+			},
+			"limit": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: checkInputValidInt("limit", 0, 1000000),
+			},
+			"summary": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"total": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+						"token_status": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
 			"entitlements": &schema.Schema{
 				Type:     schema.TypeList,
 				Computed: true,
@@ -78,10 +145,9 @@ func dataSourceEntitlementsList() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"token": &schema.Schema{
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+						"token":           entitlementTokenSchema(),
+						"encrypted_token": encryptedTokenSchema(),
+						"token_sha256":    tokenSha256Schema(),
 						"token_status": &schema.Schema{
 							Type:     schema.TypeString,
 							Computed: true,
@@ -144,6 +210,24 @@ func dataSourceEntitlementsListRead(d *schema.ResourceData, m interface{}) error
 		return nil
 	}
 
+	// Filter, sort and limit locally
+	entitlements, err := filterEntitlementsList(o["entitlements"], d.Get("filter").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if err = d.Set("summary", summarizeEntitlementsList(entitlements)); err != nil {
+		return fmt.Errorf("error reading summary: %v", err)
+	}
+	if v, ok := d.GetOk("sort_by"); ok {
+		sortEntitlementsList(entitlements, v.(string), d.Get("sort_order").(string) == "desc")
+	}
+	if limit := d.Get("limit").(int); limit > 0 && len(entitlements) > limit {
+		entitlements = entitlements[:limit]
+	}
+	if o["entitlements"] != nil {
+		o["entitlements"] = entitlements
+	}
+
 	// Update status
 	err = dataSourceRefreshObjectEntitlementsList(d, o)
 	if err != nil {
@@ -158,7 +242,11 @@ func dataSourceEntitlementsListRead(d *schema.ResourceData, m interface{}) error
 func dataSourceRefreshObjectEntitlementsList(d *schema.ResourceData, o map[string]interface{}) error {
 	var err error
 
-	if err = d.Set("entitlements", dataSourceFlattenEntitlementsListEntitlements(o["entitlements"])); err != nil {
+	entitlements := dataSourceFlattenEntitlementsListEntitlements(o["entitlements"])
+	if err = storeEntitlementTokens(d, entitlements, nil); err != nil {
+		return err
+	}
+	if err = d.Set("entitlements", entitlements); err != nil {
 		if !fortiAPIPatch(o["entitlements"]) {
 			return fmt.Errorf("error reading entitlements: %v", err)
 		}
@@ -214,3 +302,155 @@ func dataSourceFlattenEntitlementsListEntitlements(v interface{}) []map[string]i
 
 	return result
 }
+
+// ENTITLEMENT_LIST_ATTRIBUTES are the attributes of an entitlement used by filter and sort_by.
+var ENTITLEMENT_LIST_ATTRIBUTES = []string{"account_id", "config_id", "description", "serial_number",
+	"start_date", "end_date", "status", "token_status", "folder_path"}
+
+// entitlementListValue returns the value of an attribute of an entitlement in the API response.
+func entitlementListValue(entitlement map[string]interface{}, attribute string) string {
+	key := map[string]string{
+		"account_id":    "accountId",
+		"config_id":     "configId",
+		"description":   "description",
+		"serial_number": "serialNumber",
+		"start_date":    "startDate",
+		"end_date":      "endDate",
+		"status":        "status",
+		"token_status":  "tokenStatus",
+		"folder_path":   "folderPath",
+	}[attribute]
+	switch value := entitlement[key].(type) {
+	case nil:
+		return ""
+	case float64:
+		// JSON numbers, "%v" would print large IDs in exponent format
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// filterEntitlementsList returns the entitlements matching all the filters.
+func filterEntitlementsList(v interface{}, filters []interface{}) ([]interface{}, error) {
+	l, _ := v.([]interface{})
+	result := make([]interface{}, 0, len(l))
+	for _, item := range l {
+		entitlement, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matched := true
+		for _, f := range filters {
+			filter := f.(map[string]interface{})
+			ok, err := matchEntitlementFilter(entitlement, filter)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, entitlement)
+		}
+	}
+	return result, nil
+}
+
+func matchEntitlementFilter(entitlement map[string]interface{}, filter map[string]interface{}) (bool, error) {
+	attribute := filter["attribute"].(string)
+	operator := filter["operator"].(string)
+	values := make([]string, 0)
+	for _, v := range filter["values"].([]interface{}) {
+		values = append(values, fmt.Sprintf("%v", v))
+	}
+	if operator != "in" && len(values) != 1 {
+		return false, fmt.Errorf("filter %v %v requires exactly one value, got %v", attribute, operator, len(values))
+	}
+	value := entitlementListValue(entitlement, attribute)
+
+	switch operator {
+	case "eq":
+		return value == values[0], nil
+	case "ne":
+		return value != values[0], nil
+	case "in":
+		return contains(values, value), nil
+	case "prefix":
+		return strings.HasPrefix(value, values[0]), nil
+	case "regex":
+		re, err := regexp.Compile(values[0])
+		if err != nil {
+			return false, fmt.Errorf("filter %v regex: %v", attribute, err)
+		}
+		return re.MatchString(value), nil
+	case "before", "after":
+		target, err := tryParseISO8601(values[0])
+		if err != nil {
+			return false, fmt.Errorf("filter %v %v: %v", attribute, operator, err)
+		}
+		current, err := tryParseISO8601(value)
+		if err != nil {
+			return false, nil
+		}
+		if operator == "before" {
+			return current.Before(target), nil
+		}
+		return current.After(target), nil
+	}
+	return false, fmt.Errorf("unsupported filter operator: %v", operator)
+}
+
+// sortEntitlementsList sorts the entitlements by an attribute. Dates and IDs are
+// compared by value, the others as strings.
+func sortEntitlementsList(entitlements []interface{}, attribute string, descending bool) {
+	less := func(a string, b string) bool {
+		switch attribute {
+		case "account_id", "config_id":
+			a_num, _ := strconv.ParseFloat(a, 64)
+			b_num, _ := strconv.ParseFloat(b, 64)
+			return a_num < b_num
+		case "start_date", "end_date":
+			a_time, a_err := tryParseISO8601(a)
+			b_time, b_err := tryParseISO8601(b)
+			if a_err == nil && b_err == nil {
+				return a_time.Before(b_time)
+			}
+		}
+		return a < b
+	}
+	sort.SliceStable(entitlements, func(i, j int) bool {
+		a := entitlementListValue(entitlements[i].(map[string]interface{}), attribute)
+		b := entitlementListValue(entitlements[j].(map[string]interface{}), attribute)
+		if descending {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+}
+
+// summarizeEntitlementsList counts the entitlements per status and token status.
+func summarizeEntitlementsList(entitlements []interface{}) []map[string]interface{} {
+	status := make(map[string]interface{})
+	token_status := make(map[string]interface{})
+	for _, item := range entitlements {
+		entitlement := item.(map[string]interface{})
+		if value := entitlementListValue(entitlement, "status"); value != "" {
+			count, _ := status[value].(int)
+			status[value] = count + 1
+		}
+		if value := entitlementListValue(entitlement, "token_status"); value != "" {
+			count, _ := token_status[value].(int)
+			token_status[value] = count + 1
+		}
+	}
+	return []map[string]interface{}{
+		{
+			"total":        len(entitlements),
+			"status":       status,
+			"token_status": token_status,
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package fortiflexvm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchEntitlementFilter(t *testing.T) {
+	entitlement := map[string]interface{}{
+		"serialNumber": "FGVMMLTM00000001",
+		"accountId":    float64(1234567),
+		"configId":     float64(42),
+		"description":  "web server",
+		"startDate":    "2025-01-15T00:00:00",
+		"endDate":      "2025-12-31T00:00:00",
+		"status":       "ACTIVE",
+		"tokenStatus":  "USED",
+		"folderPath":   "My Assets/Web",
+	}
+	filter := func(attribute string, operator string, values ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"attribute": attribute, "operator": operator, "values": values}
+	}
+	cases := []struct {
+		name   string
+		filter map[string]interface{}
+		want   bool
+		err    bool
+	}{
+		{"eq", filter("status", "eq", "ACTIVE"), true, false},
+		{"eq other value", filter("status", "eq", "STOPPED"), false, false},
+		{"eq large number", filter("account_id", "eq", "1234567"), true, false},
+		{"ne", filter("token_status", "ne", "NOTUSED"), true, false},
+		{"in", filter("status", "in", "PENDING", "ACTIVE"), true, false},
+		{"in other values", filter("status", "in", "PENDING", "STOPPED"), false, false},
+		{"prefix", filter("folder_path", "prefix", "My Assets/"), true, false},
+		{"prefix other folder", filter("folder_path", "prefix", "Other/"), false, false},
+		{"regex", filter("description", "regex", "^web"), true, false},
+		{"regex no match", filter("description", "regex", "^db"), false, false},
+		{"invalid regex", filter("description", "regex", "("), false, true},
+		{"before", filter("end_date", "before", "2026-01-01"), true, false},
+		{"before earlier date", filter("end_date", "before", "2025-06-01"), false, false},
+		{"after", filter("start_date", "after", "2025-01-01T12:00:00Z"), true, false},
+		{"after later date", filter("start_date", "after", "2025-02-01"), false, false},
+		{"invalid date", filter("end_date", "before", "tomorrow"), false, true},
+		{"eq empty value", filter("config_id", "eq", ""), false, false},
+		{"several values", filter("status", "eq", "ACTIVE", "STOPPED"), false, true},
+		{"no value", filter("status", "eq"), false, true},
+		{"unsupported operator", filter("status", "like", "ACTIVE"), false, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := matchEntitlementFilter(entitlement, c.filter)
+			if (err != nil) != c.err {
+				t.Fatalf("matchEntitlementFilter(%v) error = %v, want error %v", c.filter, err, c.err)
+			}
+			if got != c.want {
+				t.Errorf("matchEntitlementFilter(%v) = %v, want %v", c.filter, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSortEntitlementsList(t *testing.T) {
+	entitlements := func() []interface{} {
+		return []interface{}{
+			map[string]interface{}{"serialNumber": "C", "configId": float64(10), "endDate": "2025-12-31T00:00:00", "description": "b"},
+			map[string]interface{}{"serialNumber": "A", "configId": float64(9), "endDate": "2025-02-01T00:00:00", "description": "a"},
+			map[string]interface{}{"serialNumber": "B", "configId": float64(100), "endDate": "2026-01-01T00:00:00", "description": "b"},
+			map[string]interface{}{"serialNumber": "D", "configId": float64(10), "description": "a"},
+		}
+	}
+	cases := []struct {
+		name       string
+		attribute  string
+		descending bool
+		want       []string
+	}{
+		{"string", "serial_number", false, []string{"A", "B", "C", "D"}},
+		{"string descending", "serial_number", true, []string{"D", "C", "B", "A"}},
+		{"number", "config_id", false, []string{"A", "C", "D", "B"}},
+		{"number descending", "config_id", true, []string{"B", "C", "D", "A"}},
+		{"date", "end_date", false, []string{"D", "A", "C", "B"}},
+		{"date descending", "end_date", true, []string{"B", "C", "A", "D"}},
+		{"stable", "description", false, []string{"A", "D", "C", "B"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l := entitlements()
+			sortEntitlementsList(l, c.attribute, c.descending)
+			got := make([]string, 0, len(l))
+			for _, item := range l {
+				got = append(got, item.(map[string]interface{})["serialNumber"].(string))
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("sortEntitlementsList(%v, %v) = %v, want %v", c.attribute, c.descending, got, c.want)
+			}
+		})
+	}
+}
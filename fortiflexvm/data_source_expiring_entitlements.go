@@ -0,0 +1,315 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Get the entitlements whose end date is in the next days.
+
+package fortiflexvm
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceExpiringEntitlements() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceExpiringEntitlementsRead,
+		Schema: map[string]*schema.Schema{
+			"program_serial_number": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"program_serial_number", "config_ids"},
+			},
+			"config_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"within_days": &schema.Schema{
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: checkInputValidInt("within_days", 0, 3650),
+			},
+			"program_end_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"entitlement_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"entitlements": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"config_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"account_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"folder_path": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"token_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"days_remaining": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"program_serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"program_end_date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"exceeds_program_end_date": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"groups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"config_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"count": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"serial_numbers": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"exceeding_serial_numbers": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// expiringConfig is one configuration to scan and the end date of its program.
+type expiringConfig struct {
+	id                    int
+	program_serial_number string
+	program_end_date      string
+}
+
+// expiringEntitlement is one entitlement of the result, sorted by its end date.
+type expiringEntitlement struct {
+	end_date time.Time
+	value    map[string]interface{}
+}
+
+func dataSourceExpiringEntitlementsRead(d *schema.ResourceData, m interface{}) error {
+	f := m.(*FortiClient)
+	c := f.Client
+
+	// Prepare data
+	program_serial_number := d.Get("program_serial_number").(string)
+	within_days := d.Get("within_days").(int)
+	config_ids := make([]int, 0)
+	for _, v := range d.Get("config_ids").([]interface{}) {
+		config_ids = append(config_ids, v.(int))
+	}
+
+	// Configurations to scan
+	configs := make([]expiringConfig, 0)
+	program_end_date := ""
+	if program_serial_number != "" {
+		o, err := c.ReadProgramsList(nil)
+		if err != nil {
+			return fmt.Errorf("error describing ProgramsList: %v", err)
+		}
+		program_found := false
+		program_list, _ := o["programs"].([]interface{})
+		for _, item := range program_list {
+			if program, ok := item.(map[string]interface{}); ok && fmt.Sprintf("%v", program["serialNumber"]) == program_serial_number {
+				program_found = true
+				if program["endDate"] != nil {
+					program_end_date = fmt.Sprintf("%v", program["endDate"])
+				}
+				break
+			}
+		}
+		if !program_found {
+			return fmt.Errorf("program %v not found", program_serial_number)
+		}
+		request_obj := make(map[string]interface{})
+		request_obj["programSerialNumber"] = program_serial_number
+		o, err = c.ReadConfigsList(&request_obj)
+		if err != nil {
+			return fmt.Errorf("error describing ConfigsList: %v", err)
+		}
+		config_list, _ := o["configs"].([]interface{})
+		for _, item := range config_list {
+			config, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			config_id := int(int64FromValue(config["id"]))
+			if len(config_ids) > 0 && !slices.Contains(config_ids, config_id) {
+				continue
+			}
+			configs = append(configs, expiringConfig{config_id, program_serial_number, program_end_date})
+		}
+	} else {
+		for _, config_id := range config_ids {
+			serial_number, end_date, err := f.ProgramEndDate(config_id)
+			if err != nil {
+				return fmt.Errorf("error finding the program of configuration %v: %v", config_id, err)
+			}
+			configs = append(configs, expiringConfig{config_id, serial_number, end_date})
+		}
+		// The end date of the program, if all the configurations are in the same one
+		for i, config := range configs {
+			if i > 0 && config.program_serial_number != configs[0].program_serial_number {
+				program_end_date = ""
+				break
+			}
+			program_end_date = config.program_end_date
+		}
+	}
+
+	// Entitlements whose end date is in the window
+	now := time.Now().UTC()
+	window_end := now.AddDate(0, 0, within_days)
+	found := make([]expiringEntitlement, 0)
+	exceeding_serial_numbers := make([]string, 0)
+	for _, config := range configs {
+		request_obj := make(map[string]interface{})
+		request_obj["configId"] = config.id
+		o, err := c.ReadEntitlementsList(&request_obj)
+		if err != nil {
+			return fmt.Errorf("error describing EntitlementsList of configuration %v: %v", config.id, err)
+		}
+		program_end, program_end_err := tryParseISO8601(config.program_end_date)
+		entitlement_list, _ := o["entitlements"].([]interface{})
+		for _, item := range entitlement_list {
+			entitlement, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			end_date, err := tryParseISO8601(entitlementListValue(entitlement, "end_date"))
+			if err != nil {
+				continue
+			}
+			exceeds := program_end_err == nil && end_date.After(program_end)
+			if exceeds && entitlementListValue(entitlement, "status") != "EXPIRED" {
+				exceeding_serial_numbers = append(exceeding_serial_numbers, entitlementListValue(entitlement, "serial_number"))
+			}
+			if end_date.Before(now) || end_date.After(window_end) {
+				continue
+			}
+			found = append(found, expiringEntitlement{end_date, map[string]interface{}{
+				"serial_number":            entitlementListValue(entitlement, "serial_number"),
+				"config_id":                config.id,
+				"account_id":               int(int64FromValue(entitlement["accountId"])),
+				"description":              entitlementListValue(entitlement, "description"),
+				"folder_path":              entitlementListValue(entitlement, "folder_path"),
+				"status":                   entitlementListValue(entitlement, "status"),
+				"token_status":             entitlementListValue(entitlement, "token_status"),
+				"end_date":                 entitlementListValue(entitlement, "end_date"),
+				"days_remaining":           math.Round(end_date.Sub(now).Hours()/24*100) / 100,
+				"program_serial_number":    config.program_serial_number,
+				"program_end_date":         config.program_end_date,
+				"exceeds_program_end_date": exceeds,
+			}})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].end_date.Before(found[j].end_date)
+	})
+	entitlements := make([]map[string]interface{}, 0, len(found))
+	for _, entitlement := range found {
+		entitlements = append(entitlements, entitlement.value)
+	}
+
+	// Group by configuration and status
+	group_map := make(map[string]map[string]interface{})
+	for _, entitlement := range entitlements {
+		key := fmt.Sprintf("%v.%v", entitlement["config_id"], entitlement["status"])
+		group, ok := group_map[key]
+		if !ok {
+			group = map[string]interface{}{
+				"config_id":      entitlement["config_id"],
+				"status":         entitlement["status"],
+				"count":          0,
+				"serial_numbers": make([]string, 0),
+			}
+			group_map[key] = group
+		}
+		group["count"] = group["count"].(int) + 1
+		group["serial_numbers"] = append(group["serial_numbers"].([]string), entitlement["serial_number"].(string))
+	}
+	groups := make([]map[string]interface{}, 0, len(group_map))
+	for _, group := range group_map {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i]["config_id"].(int) != groups[j]["config_id"].(int) {
+			return groups[i]["config_id"].(int) < groups[j]["config_id"].(int)
+		}
+		return groups[i]["status"].(string) < groups[j]["status"].(string)
+	})
+
+	// Update status
+	d.Set("program_end_date", program_end_date)
+	d.Set("entitlement_count", len(entitlements))
+	d.Set("entitlements", entitlements)
+	d.Set("groups", groups)
+	d.Set("exceeding_serial_numbers", exceeding_serial_numbers)
+	id_parts := make([]string, 0, len(config_ids))
+	for _, config_id := range config_ids {
+		id_parts = append(id_parts, fmt.Sprintf("%v", config_id))
+	}
+	d.SetId(fmt.Sprintf("%v.%v.%v", program_serial_number, strings.Join(id_parts, "-"), within_days))
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Get the token pool statistics of one group (asset folder).
+
+package fortiflexvm
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGroupRead,
+		Schema: map[string]*schema.Schema{
+			"folder_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"account_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"include_subfolders": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"available_tokens": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"used_tokens": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"entitlement_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"token_status": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"config_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"oldest_unused_serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"oldest_unused_token_age_days": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGroupRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*FortiClient).Client
+
+	// Prepare data
+	folder_path := strings.TrimSuffix(d.Get("folder_path").(string), "/")
+	account_id := d.Get("account_id").(int)
+	include_subfolders := d.Get("include_subfolders").(bool)
+	in_group := func(path string) bool {
+		path = strings.TrimSuffix(path, "/")
+		return path == folder_path || (include_subfolders && strings.HasPrefix(path, folder_path+"/"))
+	}
+
+	// Token counts of the folder
+	request_obj := make(map[string]interface{})
+	if account_id != 0 {
+		request_obj["accountId"] = account_id
+	}
+	o, err := c.ReadGroupsList(&request_obj)
+	if err != nil {
+		return fmt.Errorf("error describing GroupsList: %v", err)
+	}
+	found := false
+	available_tokens := 0
+	used_tokens := 0
+	group_list, _ := o["groups"].([]interface{})
+	for _, item := range group_list {
+		group, ok := item.(map[string]interface{})
+		if !ok || !in_group(fmt.Sprintf("%v", group["folderPath"])) {
+			continue
+		}
+		found = true
+		available_tokens += int(int64FromValue(group["availableTokens"]))
+		used_tokens += int(int64FromValue(group["usedTokens"]))
+		if account_id == 0 {
+			account_id = int(int64FromValue(group["accountId"]))
+		}
+	}
+	if !found {
+		return fmt.Errorf("group %v not found", d.Get("folder_path"))
+	}
+
+	// The entitlements list can't be filtered by folder, read the entitlements of each program
+	o, err = c.ReadProgramsList(nil)
+	if err != nil {
+		return fmt.Errorf("error describing ProgramsList: %v", err)
+	}
+	status := make(map[string]interface{})
+	token_status := make(map[string]interface{})
+	config_id_map := make(map[int]bool)
+	entitlement_count := 0
+	oldest_serial_number := ""
+	var oldest_start time.Time
+	program_list, _ := o["programs"].([]interface{})
+	for _, item := range program_list {
+		program, ok := item.(map[string]interface{})
+		if !ok || (account_id != 0 && int(int64FromValue(program["accountId"])) != account_id) {
+			continue
+		}
+		request_obj = make(map[string]interface{})
+		request_obj["accountId"] = program["accountId"]
+		request_obj["programSerialNumber"] = program["serialNumber"]
+		o, err := c.ReadEntitlementsList(&request_obj)
+		if err != nil {
+			return fmt.Errorf("error describing EntitlementsList of program %v: %v", program["serialNumber"], err)
+		}
+		entitlement_list, _ := o["entitlements"].([]interface{})
+		for _, ent := range entitlement_list {
+			entitlement, ok := ent.(map[string]interface{})
+			if !ok || !in_group(entitlementListValue(entitlement, "folder_path")) {
+				continue
+			}
+			entitlement_count += 1
+			if value := entitlementListValue(entitlement, "status"); value != "" {
+				count, _ := status[value].(int)
+				status[value] = count + 1
+			}
+			if value := entitlementListValue(entitlement, "token_status"); value != "" {
+				count, _ := token_status[value].(int)
+				token_status[value] = count + 1
+			}
+			config_id_map[int(int64FromValue(entitlement["configId"]))] = true
+			if entitlementListValue(entitlement, "token_status") == "NOTUSED" {
+				start, err := tryParseISO8601(entitlementListValue(entitlement, "start_date"))
+				if err == nil && (oldest_serial_number == "" || start.Before(oldest_start)) {
+					oldest_start = start
+					oldest_serial_number = entitlementListValue(entitlement, "serial_number")
+				}
+			}
+		}
+	}
+	config_ids := make([]int, 0, len(config_id_map))
+	for config_id := range config_id_map {
+		config_ids = append(config_ids, config_id)
+	}
+	sort.Ints(config_ids)
+	oldest_age := -1.0
+	if oldest_serial_number != "" {
+		oldest_age = math.Round(time.Since(oldest_start).Hours()/24*100) / 100
+	}
+
+	// Update status
+	d.Set("account_id", account_id)
+	d.Set("available_tokens", available_tokens)
+	d.Set("used_tokens", used_tokens)
+	d.Set("entitlement_count", entitlement_count)
+	d.Set("status", status)
+	d.Set("token_status", token_status)
+	d.Set("config_ids", config_ids)
+	d.Set("oldest_unused_serial_number", oldest_serial_number)
+	d.Set("oldest_unused_token_age_days", oldest_age)
+	d.SetId(fmt.Sprintf("%v.%v", account_id, d.Get("folder_path")))
+
+	return nil
+}
@@ -7,15 +7,31 @@
 package fortiflexvm
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	fortisdk "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
 )
 
+// ErrNoTokenAvailable is returned by WaitGroupsNexttoken if no token appears before the timeout.
+var ErrNoTokenAvailable = errors.New("no token available")
+
+// NEXTTOKEN_TIMEOUT_SUMMARY is the summary of the error reported when WaitGroupsNexttoken times out.
+const NEXTTOKEN_TIMEOUT_SUMMARY = "Timed out waiting for an available token"
+
+// MAX_POLL_INTERVAL is the upper bound of the backoff between two polling requests.
+const MAX_POLL_INTERVAL = 60 * time.Second
+
 func dataSourceGroupsNexttoken() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGroupsNexttokenRead,
+		ReadContext: dataSourceGroupsNexttokenRead,
 		Schema: map[string]*schema.Schema{
 			"account_id": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -36,6 +52,20 @@ func dataSourceGroupsNexttoken() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"wait_timeout": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: checkInputValidInt("wait_timeout", 0, 86400),
+			},
+			"poll_interval": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          10,
+				ValidateDiagFunc: checkInputValidInt("poll_interval", 1, 3600),
+			},
+			"token_storage": tokenStorageSchema(),
+			"pgp_key":       pgpKeySchema(),
 			"entitlements": &schema.Schema{
 				Type:     schema.TypeList,
 				Computed: true,
@@ -69,10 +99,9 @@ func dataSourceGroupsNexttoken() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"token": &schema.Schema{
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+						"token":           entitlementTokenSchema(),
+						"encrypted_token": encryptedTokenSchema(),
+						"token_sha256":    tokenSha256Schema(),
 						"token_status": &schema.Schema{
 							Type:     schema.TypeString,
 							Computed: true,
@@ -84,7 +113,7 @@ func dataSourceGroupsNexttoken() *schema.Resource {
 	}
 }
 
-func dataSourceGroupsNexttokenRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceGroupsNexttokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*FortiClient).Client
 
 	// Prepare data
@@ -107,13 +136,22 @@ func dataSourceGroupsNexttokenRead(d *schema.ResourceData, m interface{}) error
 		request_obj["status"] = v
 	}
 	if len(request_obj) == 0 {
-		return fmt.Errorf("either config_id or folder_path is required")
+		return diag.Errorf("either config_id or folder_path is required")
 	}
 
 	// Send request
-	o, err := c.ReadGroupsNexttoken(&request_obj)
+	wait_timeout := time.Duration(d.Get("wait_timeout").(int)) * time.Second
+	poll_interval := time.Duration(d.Get("poll_interval").(int)) * time.Second
+	o, err := WaitGroupsNexttoken(ctx, c, request_obj, wait_timeout, poll_interval)
+	if errors.Is(err, ErrNoTokenAvailable) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  NEXTTOKEN_TIMEOUT_SUMMARY,
+			Detail:   NexttokenTimeoutDetail(request_obj, wait_timeout, err),
+		}}
+	}
 	if err != nil {
-		return fmt.Errorf("error describing GroupsNexttoken: %v", err)
+		return diag.Errorf("error describing GroupsNexttoken: %v", err)
 	}
 
 	if o == nil {
@@ -124,7 +162,7 @@ func dataSourceGroupsNexttokenRead(d *schema.ResourceData, m interface{}) error
 	// Update status
 	err = dataSourceRefreshObjectGroupsNexttoken(d, o)
 	if err != nil {
-		return fmt.Errorf("error describing GroupsNexttoken from API: %v", err)
+		return diag.Errorf("error describing GroupsNexttoken from API: %v", err)
 	}
 
 	resource_id := fmt.Sprintf("%v.%v", config_id, folder_path)
@@ -133,25 +171,83 @@ func dataSourceGroupsNexttokenRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func dataSourceRefreshObjectGroupsNexttoken(d *schema.ResourceData, o map[string]interface{}) error {
-	var err error
+// WaitGroupsNexttoken requests the next token until the response contains an entitlement.
+// If wait_timeout is 0, it sends only one request and returns the response as it is.
+// Otherwise, failed and empty responses are retried, the interval between two requests
+// starts at poll_interval and doubles up to MAX_POLL_INTERVAL.
+func WaitGroupsNexttoken(ctx context.Context, c *fortisdk.FortiSDKClient, request_obj map[string]interface{}, wait_timeout time.Duration, poll_interval time.Duration) (map[string]interface{}, error) {
+	if wait_timeout <= 0 {
+		return c.ReadGroupsNexttoken(&request_obj)
+	}
+	deadline := time.Now().Add(wait_timeout)
+	interval := poll_interval
+	attempt := 0
+	for {
+		attempt += 1
+		o, err := c.ReadGroupsNexttoken(&request_obj)
+		if err == nil && len(NexttokenEntitlements(o)) > 0 {
+			return o, nil
+		}
+		last_error := "the response contains no entitlement"
+		if err != nil {
+			last_error = err.Error()
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("%w after %v attempt(s) in %v, last response: %v", ErrNoTokenAvailable, attempt, wait_timeout, last_error)
+		}
+		sleep := interval
+		if sleep > remaining {
+			sleep = remaining
+		}
+		log.Printf("[INFO] No token available yet (attempt %v), retry in %v: %v", attempt, sleep, last_error)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sleep):
+		}
+		if interval < MAX_POLL_INTERVAL {
+			interval = min(interval*2, MAX_POLL_INTERVAL)
+		}
+	}
+}
+
+// NexttokenEntitlements returns the entitlements of a nexttoken response as a list.
+func NexttokenEntitlements(o map[string]interface{}) []interface{} {
 	switch v := o["entitlements"].(type) {
 	case map[string]interface{}:
-		entitlements_list := make([]interface{}, 0, 1)
-		entitlements_list = append(entitlements_list, o["entitlements"].(map[string]interface{}))
-		if err = d.Set("entitlements", dataSourceFlattenGroupsNexttokenEntitlements(entitlements_list)); err != nil {
-			if !fortiAPIPatch(o["entitlements"]) {
-				return fmt.Errorf("error reading entitlements: %v", err)
-			}
-		}
+		return []interface{}{v}
 	case []interface{}:
-		if err = d.Set("entitlements", dataSourceFlattenGroupsNexttokenEntitlements(o["entitlements"])); err != nil {
-			if !fortiAPIPatch(o["entitlements"]) {
-				return fmt.Errorf("error reading entitlements: %v", err)
+		result := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			if _, ok := item.(map[string]interface{}); ok {
+				result = append(result, item)
 			}
 		}
-	default:
-		fmt.Printf("Unsupported type: %T\n", v)
+		return result
+	}
+	return nil
+}
+
+// NexttokenTimeoutDetail describes a nexttoken lookup that found no token before wait_timeout.
+func NexttokenTimeoutDetail(request_obj map[string]interface{}, wait_timeout time.Duration, err error) string {
+	filters := make([]string, 0)
+	for _, key := range []string{"configId", "folderPath", "accountId", "status"} {
+		if v, ok := request_obj[key]; ok {
+			filters = append(filters, fmt.Sprintf("%v=%v", key, v))
+		}
+	}
+	return fmt.Sprintf("No unused token matching %v was found within wait_timeout (%v). ", strings.Join(filters, ", "), wait_timeout) +
+		"Create more entitlements for this configuration or folder (e.g. with fortiflexvm_token_pool), or increase wait_timeout.\n" + err.Error()
+}
+
+func dataSourceRefreshObjectGroupsNexttoken(d *schema.ResourceData, o map[string]interface{}) error {
+	entitlements := dataSourceFlattenGroupsNexttokenEntitlements(NexttokenEntitlements(o))
+	if err := storeEntitlementTokens(d, entitlements, nil); err != nil {
+		return err
+	}
+	if err := d.Set("entitlements", entitlements); err != nil {
+		return fmt.Errorf("error reading entitlements: %v", err)
 	}
 	return nil
 }
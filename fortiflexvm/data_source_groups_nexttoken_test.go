@@ -0,0 +1,117 @@
+package fortiflexvm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/auth"
+	fortisdk "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
+)
+
+const (
+	nexttokenFound = `{"status": 0, "entitlements": {"serialNumber": "FGVMMLTM00000001", "token": "TOKEN"}}`
+	nexttokenList  = `{"status": 0, "entitlements": [{"serialNumber": "FGVMMLTM00000001", "token": "TOKEN"}]}`
+	nexttokenEmpty = `{"status": 0, "entitlements": []}`
+	nexttokenError = `Service Unavailable`
+)
+
+// nexttokenTransport answers the requests with 'bodies' in order, then with the last one.
+type nexttokenTransport struct {
+	bodies   []string
+	requests int
+}
+
+func (tr *nexttokenTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	body := tr.bodies[min(tr.requests, len(tr.bodies)-1)]
+	tr.requests += 1
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+		Request:    request,
+	}, nil
+}
+
+func TestWaitGroupsNexttoken(t *testing.T) {
+	cases := []struct {
+		name         string
+		bodies       []string
+		wait_timeout time.Duration
+		requests     int
+		entitlements int
+		err          error
+	}{
+		{"no wait", []string{nexttokenEmpty}, 0, 1, 0, nil},
+		{"no wait found", []string{nexttokenFound}, 0, 1, 1, nil},
+		{"found", []string{nexttokenFound}, time.Second, 1, 1, nil},
+		{"found as a list", []string{nexttokenList}, time.Second, 1, 1, nil},
+		{"found after empty responses", []string{nexttokenEmpty, nexttokenEmpty, nexttokenFound}, 5 * time.Second, 3, 1, nil},
+		{"found after an error", []string{nexttokenError, nexttokenFound}, 5 * time.Second, 2, 1, nil},
+		{"timeout", []string{nexttokenEmpty}, 50 * time.Millisecond, -1, 0, ErrNoTokenAvailable},
+		{"timeout with errors", []string{nexttokenError}, 50 * time.Millisecond, -1, 0, ErrNoTokenAvailable},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			transport := &nexttokenTransport{bodies: c.bodies}
+			client := &fortisdk.FortiSDKClient{Auth: &auth.Auth{Token: "test"}, HTTPCon: &http.Client{Transport: transport}}
+			request_obj := map[string]interface{}{"configId": 42}
+			o, err := WaitGroupsNexttoken(context.Background(), client, request_obj, c.wait_timeout, 10*time.Millisecond)
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("WaitGroupsNexttoken() error = %v, want %v", err, c.err)
+				}
+				if transport.requests < 2 {
+					t.Errorf("WaitGroupsNexttoken() sent %v request(s) before the timeout, want several", transport.requests)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WaitGroupsNexttoken() returned error: %v", err)
+			}
+			if got := len(NexttokenEntitlements(o)); got != c.entitlements {
+				t.Errorf("WaitGroupsNexttoken() returned %v entitlement(s), want %v", got, c.entitlements)
+			}
+			if transport.requests != c.requests {
+				t.Errorf("WaitGroupsNexttoken() sent %v request(s), want %v", transport.requests, c.requests)
+			}
+		})
+	}
+}
+
+func TestWaitGroupsNexttokenCanceled(t *testing.T) {
+	transport := &nexttokenTransport{bodies: []string{nexttokenEmpty}}
+	client := &fortisdk.FortiSDKClient{Auth: &auth.Auth{Token: "test"}, HTTPCon: &http.Client{Transport: transport}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := WaitGroupsNexttoken(ctx, client, map[string]interface{}{"configId": 42}, time.Hour, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitGroupsNexttoken() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestNexttokenEntitlements(t *testing.T) {
+	cases := []struct {
+		name string
+		o    map[string]interface{}
+		want int
+	}{
+		{"object", map[string]interface{}{"entitlements": map[string]interface{}{"serialNumber": "A"}}, 1},
+		{"list", map[string]interface{}{"entitlements": []interface{}{map[string]interface{}{"serialNumber": "A"}, map[string]interface{}{"serialNumber": "B"}}}, 2},
+		{"list with invalid items", map[string]interface{}{"entitlements": []interface{}{"A", nil, map[string]interface{}{"serialNumber": "B"}}}, 1},
+		{"empty list", map[string]interface{}{"entitlements": []interface{}{}}, 0},
+		{"no entitlements", map[string]interface{}{}, 0},
+		{"nil response", nil, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := len(NexttokenEntitlements(c.o)); got != c.want {
+				t.Errorf("NexttokenEntitlements(%v) returned %v entitlement(s), want %v", c.o, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Get point usage of several configurations, grouped for chargeback.
+
+package fortiflexvm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePointsReport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePointsReportRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"config_ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"program_serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"start_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"end_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_by": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "config",
+				ValidateDiagFunc: checkInputValidString("group_by", []string{"config", "folder", "description_prefix", "status", "month"}),
+			},
+			"description_separator": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "-",
+			},
+			"total_points": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"entitlement_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"groups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"points": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"entitlement_count": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// pointsReportGroup is the points used by the entitlements of one group.
+type pointsReportGroup struct {
+	points  float64
+	serials map[string]bool
+}
+
+func dataSourcePointsReportRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*FortiClient).Client
+
+	// Prepare data
+	account_id := d.Get("account_id").(int)
+	program_serial_number := d.Get("program_serial_number").(string)
+	group_by := d.Get("group_by").(string)
+	separator := d.Get("description_separator").(string)
+	config_ids := make([]int, 0)
+	for _, v := range d.Get("config_ids").([]interface{}) {
+		config_ids = append(config_ids, v.(int))
+	}
+	if (len(config_ids) == 0) == (program_serial_number == "") {
+		return fmt.Errorf("either config_ids or program_serial_number is required")
+	}
+	start_date, err := tryParseISO8601(d.Get("start_date").(string))
+	if err != nil {
+		return fmt.Errorf("invalid start_date: %v", err)
+	}
+	end_date, err := tryParseISO8601(d.Get("end_date").(string))
+	if err != nil {
+		return fmt.Errorf("invalid end_date: %v", err)
+	}
+	if end_date.Before(start_date) {
+		return fmt.Errorf("end_date %v is before start_date %v", d.Get("end_date"), d.Get("start_date"))
+	}
+
+	if program_serial_number != "" {
+		request_obj := make(map[string]interface{})
+		request_obj["programSerialNumber"] = program_serial_number
+		if account_id != 0 {
+			request_obj["accountId"] = account_id
+		}
+		o, err := c.ReadConfigsList(&request_obj)
+		if err != nil {
+			return fmt.Errorf("error describing ConfigsList: %v", err)
+		}
+		config_list, _ := o["configs"].([]interface{})
+		for _, config := range config_list {
+			if config_map, ok := config.(map[string]interface{}); ok {
+				config_ids = append(config_ids, int(int64FromValue(config_map["id"])))
+			}
+		}
+	}
+
+	groups := make(map[string]*pointsReportGroup)
+	all_serials := make(map[string]bool)
+	total_points := 0.0
+	for _, config_id := range config_ids {
+		// Folder, description and status are not in the points response
+		entitlements := make(map[string]map[string]interface{})
+		if group_by == "folder" || group_by == "description_prefix" || group_by == "status" {
+			request_obj := make(map[string]interface{})
+			request_obj["configId"] = config_id
+			if account_id != 0 {
+				request_obj["accountId"] = account_id
+			}
+			o, err := c.ReadEntitlementsList(&request_obj)
+			if err != nil {
+				return fmt.Errorf("error describing EntitlementsList of config %v: %v", config_id, err)
+			}
+			entitlement_list, _ := o["entitlements"].([]interface{})
+			for _, item := range entitlement_list {
+				if entitlement, ok := item.(map[string]interface{}); ok {
+					entitlements[fmt.Sprintf("%v", entitlement["serialNumber"])] = entitlement
+				}
+			}
+		}
+
+		for _, window := range pointsReportWindows(start_date, end_date) {
+			request_obj := make(map[string]interface{})
+			request_obj["configId"] = config_id
+			request_obj["startDate"] = window[0].Format("2006-01-02")
+			request_obj["endDate"] = window[1].Format("2006-01-02")
+			if account_id != 0 {
+				request_obj["accountId"] = account_id
+			}
+			o, err := c.ReadEntitlementsPoint(&request_obj)
+			if err != nil {
+				return fmt.Errorf("error describing EntitlementsPoint of config %v from %v to %v: %v",
+					config_id, request_obj["startDate"], request_obj["endDate"], err)
+			}
+			point_list, _ := o["entitlements"].([]interface{})
+			for _, item := range point_list {
+				point_map, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				serial_number := fmt.Sprintf("%v", point_map["serialNumber"])
+				points, _ := point_map["points"].(float64)
+
+				var key string
+				switch group_by {
+				case "config":
+					key = fmt.Sprintf("%v", config_id)
+				case "month":
+					key = window[0].Format("2006-01")
+				default:
+					key = pointsReportKey(entitlements[serial_number], group_by, separator)
+				}
+				group, ok := groups[key]
+				if !ok {
+					group = &pointsReportGroup{serials: make(map[string]bool)}
+					groups[key] = group
+				}
+				group.points += points
+				group.serials[serial_number] = true
+				all_serials[serial_number] = true
+				total_points += points
+			}
+		}
+	}
+
+	// Update status
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	group_list := make([]map[string]interface{}, 0, len(keys))
+	for _, key := range keys {
+		group_list = append(group_list, map[string]interface{}{
+			"key":               key,
+			"points":            groups[key].points,
+			"entitlement_count": len(groups[key].serials),
+		})
+	}
+	if err = d.Set("groups", group_list); err != nil {
+		return fmt.Errorf("error reading groups: %v", err)
+	}
+	d.Set("total_points", total_points)
+	d.Set("entitlement_count", len(all_serials))
+
+	scope := program_serial_number
+	if scope == "" {
+		id_list := make([]string, 0, len(config_ids))
+		for _, config_id := range config_ids {
+			id_list = append(id_list, fmt.Sprintf("%v", config_id))
+		}
+		scope = strings.Join(id_list, "-")
+	}
+	d.SetId(fmt.Sprintf("%v.%v.%v.%v", scope, d.Get("start_date"), d.Get("end_date"), group_by))
+
+	return nil
+}
+
+// pointsReportWindows splits the date range into calendar months, so each
+// request of the points API covers at most 31 days.
+func pointsReportWindows(start_date time.Time, end_date time.Time) [][2]time.Time {
+	windows := make([][2]time.Time, 0)
+	window_start := time.Date(start_date.Year(), start_date.Month(), start_date.Day(), 0, 0, 0, 0, time.UTC)
+	last_date := time.Date(end_date.Year(), end_date.Month(), end_date.Day(), 0, 0, 0, 0, time.UTC)
+	for !window_start.After(last_date) {
+		window_end := time.Date(window_start.Year(), window_start.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		if window_end.After(last_date) {
+			window_end = last_date
+		}
+		windows = append(windows, [2]time.Time{window_start, window_end})
+		window_start = window_end.AddDate(0, 0, 1)
+	}
+	return windows
+}
+
+func pointsReportKey(entitlement map[string]interface{}, group_by string, separator string) string {
+	if entitlement == nil {
+		return ""
+	}
+	switch group_by {
+	case "folder":
+		if v, ok := entitlement["folderPath"]; ok && v != nil {
+			return fmt.Sprintf("%v", v)
+		}
+	case "status":
+		if v, ok := entitlement["status"]; ok && v != nil {
+			return fmt.Sprintf("%v", v)
+		}
+	case "description_prefix":
+		if v, ok := entitlement["description"]; ok && v != nil {
+			description := fmt.Sprintf("%v", v)
+			if separator == "" {
+				return description
+			}
+			return strings.SplitN(description, separator, 2)[0]
+		}
+	}
+	return ""
+}
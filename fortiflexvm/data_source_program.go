@@ -0,0 +1,179 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Get one program with its points balance and consumption.
+
+package fortiflexvm
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceProgram() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceProgramRead,
+		Schema: map[string]*schema.Schema{
+			"serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"consumption_days": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				ValidateDiagFunc: checkInputValidInt("consumption_days", 1, 365),
+			},
+			"account_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"start_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"end_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"has_support_coverage": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"mode": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"points_balance": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"consumed_points": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"daily_consumption_rate": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"days_remaining": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"projected_exhaustion_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceProgramRead(d *schema.ResourceData, m interface{}) error {
+	f := m.(*FortiClient)
+	c := f.Client
+
+	serial_number := d.Get("serial_number").(string)
+	consumption_days := d.Get("consumption_days").(int)
+
+	// Find the program
+	o, err := c.ReadProgramsList(nil)
+	if err != nil {
+		return fmt.Errorf("error describing ProgramsList: %v", err)
+	}
+	var program map[string]interface{}
+	program_list, _ := o["programs"].([]interface{})
+	for _, item := range program_list {
+		if program_map, ok := item.(map[string]interface{}); ok && fmt.Sprintf("%v", program_map["serialNumber"]) == serial_number {
+			program = program_map
+			break
+		}
+	}
+	if program == nil {
+		return fmt.Errorf("program %v not found", serial_number)
+	}
+	d.Set("account_id", program["accountId"])
+	d.Set("start_date", program["startDate"])
+	d.Set("end_date", program["endDate"])
+	d.Set("has_support_coverage", program["hasSupportCoverage"])
+
+	// FortiFlex only reports a points balance for prepaid programs
+	request_obj := make(map[string]interface{})
+	request_obj["programSerialNumber"] = serial_number
+	if v, ok := program["accountId"]; ok && v != nil {
+		request_obj["accountId"] = v
+	}
+	mode := "postpaid"
+	points_balance := 0.0
+	o, err = c.ReadProgramsPoints(&request_obj)
+	if err != nil {
+		return fmt.Errorf("error describing ProgramsPoints: %v", err)
+	}
+	if balance, ok := programPointsBalance(o["programs"], serial_number); ok {
+		mode = "prepaid"
+		points_balance = balance
+	}
+	d.Set("mode", mode)
+	d.Set("points_balance", points_balance)
+
+	// Consumption of all the configurations of the program
+	request_obj = make(map[string]interface{})
+	request_obj["programSerialNumber"] = serial_number
+	o, err = c.ReadConfigsList(&request_obj)
+	if err != nil {
+		return fmt.Errorf("error describing ConfigsList: %v", err)
+	}
+	config_ids := make([]int, 0)
+	config_list, _ := o["configs"].([]interface{})
+	for _, config := range config_list {
+		if config_map, ok := config.(map[string]interface{}); ok {
+			config_ids = append(config_ids, int(int64FromValue(config_map["id"])))
+		}
+	}
+	consumed_points, err := f.readUsedPoints(config_ids, consumption_days)
+	if err != nil {
+		return err
+	}
+	daily_rate := consumed_points / float64(consumption_days)
+	d.Set("consumed_points", consumed_points)
+	d.Set("daily_consumption_rate", daily_rate)
+
+	// Project the exhaustion of a prepaid program at the current rate
+	days_remaining := -1.0
+	exhaustion_date := ""
+	if mode == "prepaid" && daily_rate > 0 {
+		days_remaining = points_balance / daily_rate
+		exhaustion := time.Now().UTC().Add(time.Duration(days_remaining * float64(24*time.Hour)))
+		exhaustion_date = exhaustion.Format("2006-01-02")
+		days_remaining = math.Round(days_remaining*100) / 100
+	}
+	d.Set("days_remaining", days_remaining)
+	d.Set("projected_exhaustion_date", exhaustion_date)
+
+	d.SetId(serial_number)
+
+	return nil
+}
+
+// programPointsBalance returns the balance of the program in the response of ReadProgramsPoints.
+func programPointsBalance(v interface{}, serial_number string) (float64, bool) {
+	l, _ := v.([]interface{})
+	for _, item := range l {
+		program, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if sn, ok := program["serialNumber"]; ok && fmt.Sprintf("%v", sn) != serial_number {
+			continue
+		}
+		for _, key := range []string{"availablePoints", "pointsBalance", "balance"} {
+			if balance, ok := program[key].(float64); ok {
+				return balance, true
+			}
+		}
+	}
+	return 0, false
+}
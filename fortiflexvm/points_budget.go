@@ -0,0 +1,370 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Check the points budget before entitlements are created or reactivated.
+
+package fortiflexvm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	fortisdk "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
+)
+
+// ErrPointsBudgetExceeded is returned when budget_enforcement is "block" and
+// the new entitlements would exceed a points budget.
+var ErrPointsBudgetExceeded = errors.New("points budget exceeded")
+
+// PointsBudget limits the points used by a program or a configuration.
+type PointsBudget struct {
+	ProgramSerialNumber string
+	ConfigID            int
+	Daily               float64
+	Monthly             float64
+}
+
+// pointsBudgetState is shared by all the checks of one provider instance. The
+// points API only reports past days, so the points added in this run are
+// tracked here. The configurations are read from FortiClient.configs. 'reserved' is the estimated daily points of one entitlement of
+// each checked configuration, and 'serials' the configuration of each checked
+// serial number, so the points can be released if the operation fails.
+type pointsBudgetState struct {
+	sync.Mutex
+	added    map[int]float64
+	reserved map[int]float64
+	serials  map[string]int
+}
+
+func pointsBudgetSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Limit the points used by a program or a configuration.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"program_serial_number": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The serial number of the program. Set either program_serial_number or config_id.",
+				},
+				"config_id": &schema.Schema{
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: "The ID of the configuration. Set either program_serial_number or config_id.",
+				},
+				"daily": &schema.Schema{
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Description: "The maximum points used in one day.",
+				},
+				"monthly": &schema.Schema{
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Description: "The maximum points used in 30 days.",
+				},
+			},
+		},
+	}
+}
+
+func expandPointsBudgets(v interface{}) ([]PointsBudget, error) {
+	l, _ := v.([]interface{})
+	budgets := make([]PointsBudget, 0, len(l))
+	for _, item := range l {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		budget := PointsBudget{
+			ProgramSerialNumber: m["program_serial_number"].(string),
+			ConfigID:            m["config_id"].(int),
+			Daily:               m["daily"].(float64),
+			Monthly:             m["monthly"].(float64),
+		}
+		if (budget.ProgramSerialNumber == "") == (budget.ConfigID == 0) {
+			return nil, fmt.Errorf("points_budget: set either program_serial_number or config_id")
+		}
+		if budget.Daily <= 0 && budget.Monthly <= 0 {
+			return nil, fmt.Errorf("points_budget: set daily or monthly")
+		}
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
+
+// CheckPointsBudget checks whether 'entitlement_num' new active entitlements of
+// the configuration fit in the points budgets. The budget is compared with the
+// points used yesterday (daily) or in the last 30 days (monthly), plus the points
+// added in this run and the estimated points of the new entitlements.
+// It returns warnings, or an error wrapping ErrPointsBudgetExceeded if
+// budget_enforcement is "block".
+func (f *FortiClient) CheckPointsBudget(config_id int, entitlement_num int) ([]string, error) {
+	if f == nil || len(f.PointsBudgets) == 0 || entitlement_num <= 0 {
+		return nil, nil
+	}
+	f.budgetState.Lock()
+	defer f.budgetState.Unlock()
+
+	config, err := f.findBudgetConfig(config_id)
+	if err != nil {
+		return nil, fmt.Errorf("error checking the points budget: %v", err)
+	}
+	program_serial_number := fmt.Sprintf("%v", config["programSerialNumber"])
+	indexes := f.budgetIndexes(config_id, program_serial_number)
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	warnings := make([]string, 0)
+	per_entitlement, err := f.estimateConfigDailyPoints(config)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Unable to estimate the points of configuration %v, only the current usage is checked: %v", config_id, err))
+	}
+	new_daily := per_entitlement * float64(entitlement_num)
+
+	violations := make([]string, 0)
+	for _, i := range indexes {
+		budget := f.PointsBudgets[i]
+		scope := fmt.Sprintf("program %v", budget.ProgramSerialNumber)
+		config_ids := []int{config_id}
+		if budget.ConfigID != 0 {
+			scope = fmt.Sprintf("configuration %v", budget.ConfigID)
+		} else {
+			configs, err := f.configs.configs(program_serial_number)
+			if err != nil {
+				return warnings, err
+			}
+			config_ids = configIDs(configs)
+		}
+		added_daily := f.budgetState.added[i] + new_daily
+		if budget.Daily > 0 {
+			used, err := f.readUsedPoints(config_ids, 1)
+			if err != nil {
+				return warnings, err
+			}
+			if used+added_daily > budget.Daily {
+				violations = append(violations, fmt.Sprintf("%s: %.2f points used yesterday + %.2f points per day of new entitlements > daily budget %.2f",
+					scope, used, added_daily, budget.Daily))
+			}
+		}
+		if budget.Monthly > 0 {
+			used, err := f.readUsedPoints(config_ids, DAYS_PER_MONTH)
+			if err != nil {
+				return warnings, err
+			}
+			if used+added_daily*DAYS_PER_MONTH > budget.Monthly {
+				violations = append(violations, fmt.Sprintf("%s: %.2f points used in the last %v days + %.2f points of new entitlements > monthly budget %.2f",
+					scope, used, DAYS_PER_MONTH, added_daily*DAYS_PER_MONTH, budget.Monthly))
+			}
+		}
+	}
+
+	if len(violations) > 0 && f.BudgetEnforcement != "warn" {
+		return warnings, fmt.Errorf("%w for %v new entitlement(s) of configuration %v:\n%s",
+			ErrPointsBudgetExceeded, entitlement_num, config_id, strings.Join(violations, "\n"))
+	}
+	for _, violation := range violations {
+		warnings = append(warnings, "Points budget exceeded, "+violation)
+	}
+	for _, i := range indexes {
+		f.budgetState.added[i] += new_daily
+	}
+	f.budgetState.reserved[config_id] = per_entitlement
+	return warnings, nil
+}
+
+// ReleasePointsBudget releases the points added by CheckPointsBudget for
+// 'entitlement_num' entitlements of the configuration, when they couldn't be
+// created or reactivated.
+func (f *FortiClient) ReleasePointsBudget(config_id int, entitlement_num int) {
+	if f == nil || len(f.PointsBudgets) == 0 || entitlement_num <= 0 {
+		return
+	}
+	f.budgetState.Lock()
+	defer f.budgetState.Unlock()
+
+	per_entitlement, ok := f.budgetState.reserved[config_id]
+	if !ok {
+		return
+	}
+	config, err := f.findBudgetConfig(config_id)
+	if err != nil {
+		return
+	}
+	for _, i := range f.budgetIndexes(config_id, fmt.Sprintf("%v", config["programSerialNumber"])) {
+		f.budgetState.added[i] -= per_entitlement * float64(entitlement_num)
+		if f.budgetState.added[i] < 0 {
+			f.budgetState.added[i] = 0
+		}
+	}
+}
+
+// budgetIndexes returns the indexes of the budgets of the configuration.
+func (f *FortiClient) budgetIndexes(config_id int, program_serial_number string) []int {
+	indexes := make([]int, 0)
+	for i, budget := range f.PointsBudgets {
+		if budget.ConfigID == config_id || (budget.ConfigID == 0 && budget.ProgramSerialNumber == program_serial_number) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// CheckPointsBudgetForSerial is CheckPointsBudget for reactivating one entitlement
+// whose configuration is unknown. Entitlements outside the budgets are not checked.
+func (f *FortiClient) CheckPointsBudgetForSerial(serial_number string) ([]string, error) {
+	if f == nil || len(f.PointsBudgets) == 0 {
+		return nil, nil
+	}
+	config_ids := make([]int, 0)
+	for _, budget := range f.PointsBudgets {
+		if budget.ConfigID != 0 {
+			config_ids = append(config_ids, budget.ConfigID)
+			continue
+		}
+		configs, err := f.configs.configs(budget.ProgramSerialNumber)
+		if err != nil {
+			return nil, err
+		}
+		config_ids = append(config_ids, configIDs(configs)...)
+	}
+	for _, config_id := range config_ids {
+		request_obj := make(map[string]interface{})
+		request_obj["configId"] = config_id
+		request_obj["serialNumber"] = serial_number
+		o, err := f.Client.ReadEntitlementsList(&request_obj)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = findEntitlementFromList(o, serial_number); err == nil {
+			warnings, err := f.CheckPointsBudget(config_id, 1)
+			if err == nil {
+				f.budgetState.Lock()
+				f.budgetState.serials[serial_number] = config_id
+				f.budgetState.Unlock()
+			}
+			return warnings, err
+		}
+	}
+	return nil, nil
+}
+
+// ReleasePointsBudgetForSerial releases the points added by CheckPointsBudgetForSerial
+// when the entitlement couldn't be reactivated.
+func (f *FortiClient) ReleasePointsBudgetForSerial(serial_number string) {
+	if f == nil || len(f.PointsBudgets) == 0 {
+		return
+	}
+	f.budgetState.Lock()
+	config_id, ok := f.budgetState.serials[serial_number]
+	delete(f.budgetState.serials, serial_number)
+	f.budgetState.Unlock()
+	if ok {
+		f.ReleasePointsBudget(config_id, 1)
+	}
+}
+
+// findBudgetConfig finds the configuration in the programs of the budgets first,
+// then in the other programs.
+func (f *FortiClient) findBudgetConfig(config_id int) (map[string]interface{}, error) {
+	program_serial_numbers := make([]string, 0)
+	for _, budget := range f.PointsBudgets {
+		if budget.ProgramSerialNumber != "" && !contains(program_serial_numbers, budget.ProgramSerialNumber) {
+			program_serial_numbers = append(program_serial_numbers, budget.ProgramSerialNumber)
+		}
+	}
+	return f.configs.findConfig(config_id, program_serial_numbers)
+}
+
+// estimateConfigDailyPoints estimates the daily points of one entitlement from the
+// pricing table, or from the points used by the configuration.
+func (f *FortiClient) estimateConfigDailyPoints(config map[string]interface{}) (float64, error) {
+	table, err := fortisdk.EmbeddedPricingTable()
+	if err != nil {
+		return 0, err
+	}
+	product_type, _ := flattenConfigProductType(config["productType"]).(string)
+	parameters := make(map[string]interface{})
+	if l, ok := flattenConfigParameters(config["parameters"]).([]map[string]interface{}); ok && len(l) > 0 {
+		parameters = l[0]
+	}
+	points, table_err := table.EstimateDailyPoints(product_type, parameters)
+	if table_err == nil {
+		return points, nil
+	}
+	config_id := int(int64FromValue(config["id"]))
+	account_id := int(int64FromValue(config["accountId"]))
+	points, entitlement_num, err := f.Client.ReadAverageDailyPoints(config_id, account_id, 7)
+	if err != nil {
+		return 0, err
+	}
+	if entitlement_num == 0 {
+		return 0, table_err
+	}
+	return points, nil
+}
+
+// readUsedPoints returns the points used by the configurations in the last 'days' days.
+func (f *FortiClient) readUsedPoints(config_ids []int, days int) (float64, error) {
+	end_date := time.Now().UTC().AddDate(0, 0, -1)
+	start_date := end_date.AddDate(0, 0, 1-days)
+	total := 0.0
+	for _, config_id := range config_ids {
+		request_obj := make(map[string]interface{})
+		request_obj["configId"] = config_id
+		request_obj["startDate"] = start_date.Format("2006-01-02")
+		request_obj["endDate"] = end_date.Format("2006-01-02")
+		o, err := f.Client.ReadEntitlementsPoint(&request_obj)
+		if err != nil {
+			return 0, fmt.Errorf("error reading points of configuration %v: %v", config_id, err)
+		}
+		entitlements, _ := o["entitlements"].([]interface{})
+		for _, ent := range entitlements {
+			if ent_map, ok := ent.(map[string]interface{}); ok {
+				points, _ := ent_map["points"].(float64)
+				total += points
+			}
+		}
+	}
+	return total, nil
+}
+
+func configIDs(configs []map[string]interface{}) []int {
+	ids := make([]int, 0, len(configs))
+	for _, config := range configs {
+		ids = append(ids, int(int64FromValue(config["id"])))
+	}
+	return ids
+}
+
+func int64FromValue(v interface{}) int64 {
+	switch i := v.(type) {
+	case float64:
+		return int64(i)
+	case int:
+		return int64(i)
+	case int64:
+		return i
+	}
+	return 0
+}
+
+// pointsBudgetWarnings returns the warnings of CheckPointsBudget as diagnostics.
+func pointsBudgetWarnings(warnings []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Points budget",
+			Detail:   warning,
+		})
+	}
+	return diags
+}
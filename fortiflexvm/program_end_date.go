@@ -0,0 +1,43 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Find the end date of the program and the product type of a configuration.
+
+package fortiflexvm
+
+import (
+	"fmt"
+)
+
+// ProgramEndDate returns the serial number and the end date of the program of the
+// configuration, as returned by FortiFlex. The programs and their configurations
+// are read once per provider instance.
+func (f *FortiClient) ProgramEndDate(config_id int) (string, string, error) {
+	config, err := f.configs.findConfig(config_id, nil)
+	if err != nil {
+		return "", "", err
+	}
+	program_serial_number := fmt.Sprintf("%v", config["programSerialNumber"])
+	program, err := f.configs.findProgram(program_serial_number)
+	if err != nil {
+		return "", "", fmt.Errorf("program %v of configuration %v not found", program_serial_number, config_id)
+	}
+	if program["endDate"] == nil {
+		return program_serial_number, "", nil
+	}
+	return program_serial_number, fmt.Sprintf("%v", program["endDate"]), nil
+}
+
+// ConfigProductTypeId returns the product type ID of the configuration. The programs
+// and their configurations are read once per provider instance.
+func (f *FortiClient) ConfigProductTypeId(config_id int) (int, error) {
+	config, err := f.configs.findConfig(config_id, nil)
+	if err != nil {
+		return 0, err
+	}
+	if pt, ok := config["productType"].(map[string]interface{}); ok {
+		return int(int64FromValue(pt["id"])), nil
+	}
+	return 0, fmt.Errorf("configuration %v has no product type", config_id)
+}
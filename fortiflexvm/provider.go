@@ -23,7 +23,8 @@ func Provider() *schema.Provider {
 			"password": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "The API password.",
+				Sensitive:   true,
+				Description: "The API password. It accepts ephemeral values and is never saved in the state.",
 			},
 
 			"import_options": &schema.Schema{
@@ -32,25 +33,41 @@ func Provider() *schema.Provider {
 				Optional: true,
 				// Computed:    true,
 				Description: "Used in terraform import. Check fortiflexvm_config document for usage.",
+				Deprecated:  "Import fortiflexvm_config by identity or by the ID 'program_serial_number.config_id' instead.",
+			},
+
+			"points_budget": pointsBudgetSchema(),
+
+			"budget_enforcement": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "What to do when new entitlements exceed points_budget: \"block\" (default) fails the request, \"warn\" only warns.",
+				ValidateDiagFunc: checkInputValidString("budget_enforcement", []string{"block", "warn"}),
 			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"fortiflexvm_programs_list":       dataSourceProgramsList(),
-			"fortiflexvm_configs_list":        dataSourceConfigsList(),
-			"fortiflexvm_entitlements_list":   dataSourceEntitlementsList(),
-			"fortiflexvm_entitlements_points": dataSourceEntitlementsPoints(),
-			"fortiflexvm_groups_list":         dataSourceGroupsList(),
-			"fortiflexvm_groups_nexttoken":    dataSourceGroupsNexttoken(),
+			"fortiflexvm_programs_list":         dataSourceProgramsList(),
+			"fortiflexvm_configs_list":          dataSourceConfigsList(),
+			"fortiflexvm_entitlements_list":     dataSourceEntitlementsList(),
+			"fortiflexvm_entitlements_points":   dataSourceEntitlementsPoints(),
+			"fortiflexvm_groups_list":           dataSourceGroupsList(),
+			"fortiflexvm_groups_nexttoken":      dataSourceGroupsNexttoken(),
+			"fortiflexvm_config_cost_estimate":  dataSourceConfigCostEstimate(),
+			"fortiflexvm_points_report":         dataSourcePointsReport(),
+			"fortiflexvm_program":               dataSourceProgram(),
+			"fortiflexvm_config":                dataSourceConfig(),
+			"fortiflexvm_entitlement":           dataSourceEntitlement(),
+			"fortiflexvm_group":                 dataSourceGroup(),
+			"fortiflexvm_expiring_entitlements": dataSourceExpiringEntitlements(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"fortiflexvm_config":                resourceConfig(),
-			"fortiflexvm_entitlements_vm":       resourceEntitlementsVM(),
-			"fortiflexvm_entitlements_hardware": resourceEntitlementsHW(),
-			"fortiflexvm_entitlements_cloud":    resourceEntitlementsCloud(),
-			"fortiflexvm_entitlements_vm_token": resourceEntitlementsVMToken(),
-			"fortiflexvm_retrieve_vm_group":     resourceRetrieveVMGroup(),
+			"fortiflexvm_config":                    resourceConfig(),
+			"fortiflexvm_retrieve_vm_group":         resourceRetrieveVMGroup(),
+			"fortiflexvm_entitlements_reaper":       resourceEntitlementsReaper(),
+			"fortiflexvm_token_pool":                resourceTokenPool(),
+			"fortiflexvm_entitlement_status_waiter": resourceEntitlementStatusWaiter(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -23,7 +23,26 @@ func resourceConfig() *schema.Resource {
 		Delete: resourceConfigDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceConfigImport,
+		},
+
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: func() map[string]*schema.Schema {
+				return map[string]*schema.Schema{
+					"config_id": &schema.Schema{
+						Type:              schema.TypeInt,
+						RequiredForImport: true,
+					},
+					"program_serial_number": &schema.Schema{
+						Type:              schema.TypeString,
+						RequiredForImport: true,
+					},
+					"account_id": &schema.Schema{
+						Type:              schema.TypeInt,
+						OptionalForImport: true,
+					},
+				}
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -863,6 +882,40 @@ func resourceConfigCreate(d *schema.ResourceData, m interface{}) error {
 	}
 }
 
+// resourceConfigImport imports one configuration by its identity, or by the ID
+// 'program_serial_number.config_id'. The ID 'config_id' needs program_serial_number
+// in the deprecated provider argument import_options.
+func resourceConfigImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() == "" {
+		identity, err := d.Identity()
+		if err != nil {
+			return nil, fmt.Errorf("error getting identity: %v", err)
+		}
+		d.SetId(strconv.Itoa(identity.Get("config_id").(int)))
+		if err = d.Set("program_serial_number", identity.Get("program_serial_number")); err != nil {
+			return nil, fmt.Errorf("error set params program_serial_number: %v", err)
+		}
+		if v, ok := identity.GetOk("account_id"); ok {
+			if err = d.Set("account_id", v); err != nil {
+				return nil, fmt.Errorf("error set params account_id: %v", err)
+			}
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+
+	if split_parts := strings.Split(d.Id(), "."); len(split_parts) == 2 {
+		if _, err := strconv.Atoi(split_parts[1]); err != nil {
+			return nil, fmt.Errorf("incorrect id format: %v. Please use 'program_serial_number' + '.' + 'config_id', "+
+				"example: 'ELAVMS0000001234.123'", d.Id())
+		}
+		d.SetId(split_parts[1])
+		if err := d.Set("program_serial_number", split_parts[0]); err != nil {
+			return nil, fmt.Errorf("error set params program_serial_number: %v", err)
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceConfigRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(*FortiClient).Client
 
@@ -1060,6 +1113,15 @@ func flattenConfigParameters(v interface{}) interface{} {
 }
 
 func refreshObjectConfig(d *schema.ResourceData, o map[string]interface{}) error {
+	if err := refreshObjectConfigAttributes(d, o); err != nil {
+		return err
+	}
+	return refreshIdentityConfig(d)
+}
+
+// refreshObjectConfigAttributes sets the attributes of a configuration. It is
+// shared by the resource and the data source fortiflexvm_config.
+func refreshObjectConfigAttributes(d *schema.ResourceData, o map[string]interface{}) error {
 	var err error
 
 	if value, ok := o["accountId"]; ok {
@@ -1100,6 +1162,40 @@ func refreshObjectConfig(d *schema.ResourceData, o map[string]interface{}) error
 	return nil
 }
 
+func refreshIdentityConfig(d *schema.ResourceData) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return fmt.Errorf("error reading Config identity: %v", err)
+	}
+	if err = identity.Set("config_id", d.Get("config_id")); err != nil {
+		return fmt.Errorf("error setting identity config_id: %v", err)
+	}
+	if err = identity.Set("program_serial_number", d.Get("program_serial_number")); err != nil {
+		return fmt.Errorf("error setting identity program_serial_number: %v", err)
+	}
+	if err = identity.Set("account_id", d.Get("account_id")); err != nil {
+		return fmt.Errorf("error setting identity account_id: %v", err)
+	}
+	return nil
+}
+
+// ConfigResource returns the fortiflexvm_config resource. It is used by the list
+// resource of fortiflexvm_config to describe the resource and its identity.
+func ConfigResource() *schema.Resource {
+	return resourceConfig()
+}
+
+// ConfigResourceData builds the data of fortiflexvm_config from one configuration
+// returned by ReadConfigsList. It is used by the list resource of fortiflexvm_config.
+func ConfigResourceData(o map[string]interface{}) (*schema.ResourceData, error) {
+	d := resourceConfig().Data(nil)
+	d.SetId(fmt.Sprintf("%v", o["id"]))
+	if err := refreshObjectConfig(d, o); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
 func expandConfigProductType(d *schema.ResourceData, v interface{}, pre string) (interface{}, error) {
 	typeId := convProductTypeName2Id(v.(string))
 	if typeId == 0 {
@@ -0,0 +1,163 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Wait until an entitlement reaches a status or a token status.
+
+package fortiflexvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ErrEntitlementStatusTimeout is returned by WaitEntitlementStatus if the entitlement
+// doesn't reach the target before the context is done.
+var ErrEntitlementStatusTimeout = errors.New("timed out waiting for entitlement status")
+
+func resourceEntitlementStatusWaiter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEntitlementStatusWaiterCreate,
+		ReadContext:   resourceEntitlementStatusWaiterRead,
+		DeleteContext: resourceEntitlementStatusWaiterDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"serial_number": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"target_status": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				AtLeastOneOf:     []string{"target_status", "target_token_status"},
+				ValidateDiagFunc: checkInputValidString("target_status", []string{"PENDING", "ACTIVE", "STOPPED", "EXPIRED"}),
+			},
+			"target_token_status": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: checkInputValidString("target_token_status", []string{"NOTUSED", "USED"}),
+			},
+			"poll_interval": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          10,
+				ValidateDiagFunc: checkInputValidInt("poll_interval", 1, 3600),
+			},
+			"triggers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"token_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"elapsed_seconds": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceEntitlementStatusWaiterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resource_id := fmt.Sprintf("%v.%v", d.Get("serial_number"), d.Get("config_id"))
+	poll_interval := time.Duration(d.Get("poll_interval").(int)) * time.Second
+
+	wait_ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	start := time.Now()
+	entitlement, err := WaitEntitlementStatus(wait_ctx, resource_id, d.Get("target_status").(string), d.Get("target_token_status").(string), poll_interval, m)
+	if errors.Is(err, ErrEntitlementStatusTimeout) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Timed out waiting for entitlement status",
+			Detail:   fmt.Sprintf("%v\nIncrease the create timeout in the timeouts block to wait longer.", err),
+		}}
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource_id)
+	d.Set("status", entitlement["status"])
+	d.Set("token_status", entitlement["tokenStatus"])
+	d.Set("elapsed_seconds", int(time.Since(start).Seconds()))
+	return nil
+}
+
+func resourceEntitlementStatusWaiterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The waiter only acts during apply, there is nothing to refresh.
+	var diags diag.Diagnostics
+	return diags
+}
+
+func resourceEntitlementStatusWaiterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
+
+// WaitEntitlementStatus polls the entitlement 'resource_id' (serial_number.config_id) until
+// its status and token status are the targets. An empty target matches any value.
+// The interval between two requests starts at poll_interval and doubles up to MAX_POLL_INTERVAL.
+// It stops when ctx is done, and fails early if the entitlement expires.
+func WaitEntitlementStatus(ctx context.Context, resource_id string, status string, token_status string, poll_interval time.Duration, m interface{}) (map[string]interface{}, error) {
+	start := time.Now()
+	interval := poll_interval
+	last_state := "unknown"
+	for {
+		entitlement, diags := getEntitlementFromId(resource_id, m)
+		if diags.HasError() {
+			// The entitlement may not be listed right after it is created
+			last_state = fmt.Sprintf("error: %v", diags[0].Summary)
+		} else {
+			current_status := fmt.Sprintf("%v", entitlement["status"])
+			current_token_status := fmt.Sprintf("%v", entitlement["tokenStatus"])
+			if (status == "" || current_status == status) && (token_status == "" || current_token_status == token_status) {
+				log.Printf("[INFO] Entitlement %v reached status %v, token status %v after %v", resource_id, current_status, current_token_status, time.Since(start).Round(time.Second))
+				return entitlement, nil
+			}
+			if current_status == "EXPIRED" {
+				return nil, fmt.Errorf("entitlement %v expired while waiting for status %q and token status %q", resource_id, status, token_status)
+			}
+			last_state = fmt.Sprintf("status %v, token status %v", current_status, current_token_status)
+		}
+		log.Printf("[INFO] Waiting for entitlement %v (%v elapsed): %v, want status %q and token status %q, retry in %v",
+			resource_id, time.Since(start).Round(time.Second), last_state, status, token_status, interval)
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("%w: entitlement %v didn't reach status %q and token status %q in %v, last state: %v",
+				ErrEntitlementStatusTimeout, resource_id, status, token_status, time.Since(start).Round(time.Second), last_state)
+		case <-time.After(interval):
+		}
+		if interval < MAX_POLL_INTERVAL {
+			interval = min(interval*2, MAX_POLL_INTERVAL)
+		}
+	}
+}
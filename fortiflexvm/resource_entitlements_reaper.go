@@ -0,0 +1,210 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Release entitlements left claimed by interrupted fortiflexvm_retrieve_vm_group tasks.
+
+package fortiflexvm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceEntitlementsReaper() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEntitlementsReaperCreate,
+		ReadContext:   resourceEntitlementsReaperRead,
+		UpdateContext: resourceEntitlementsReaperUpdate,
+		DeleteContext: resourceEntitlementsReaperDelete,
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"description_pattern": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: checkInputValidRegex("description_pattern"),
+			},
+			"live_tasks": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"older_than_days": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			"refresh_token": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"dry_run": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"triggers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"reaped_entitlements": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"config_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start_date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end_date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"token": &schema.Schema{
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"token_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceEntitlementsReaperCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(fmt.Sprintf("%v.%v", d.Get("config_id"), d.Get("description_pattern")))
+	return reapOrphanedEntitlements(d, m)
+}
+
+func resourceEntitlementsReaperRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// The reaper only acts during apply, there is nothing to refresh.
+	var diags diag.Diagnostics
+	return diags
+}
+
+func resourceEntitlementsReaperUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return reapOrphanedEntitlements(d, m)
+}
+
+func resourceEntitlementsReaperDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
+
+func reapOrphanedEntitlements(d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*FortiClient).Client
+
+	config_id := d.Get("config_id").(int)
+	pattern, err := regexp.Compile(d.Get("description_pattern").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	live_tasks := []string{}
+	for _, v := range d.Get("live_tasks").([]interface{}) {
+		live_tasks = append(live_tasks, v.(string))
+	}
+	older_than_days := d.Get("older_than_days").(int)
+	refresh_token := d.Get("refresh_token").(bool)
+	dry_run := d.Get("dry_run").(bool)
+
+	// Retrieve all entitlements with the required config_id
+	request_obj := make(map[string]interface{})
+	request_obj["configId"] = config_id
+	return_obj, err := c.ReadEntitlementsList(&request_obj)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Find and release orphaned entitlements
+	result_entitlements := make([]map[string]interface{}, 0)
+	all_entitlements, _ := return_obj["entitlements"].([]interface{})
+	for _, item := range all_entitlements {
+		entitlement := item.(map[string]interface{})
+		if !isOrphanedEntitlement(entitlement, pattern, live_tasks, older_than_days) {
+			continue
+		}
+		serial_number := entitlement["serialNumber"].(string)
+		if !dry_run {
+			stop := entitlement["status"] != "STOPPED"
+			remove_diags := removeEntitlement(serial_number, config_id, stop, refresh_token, m)
+			if remove_diags.HasError() {
+				diags = append(diags, remove_diags...)
+				continue
+			}
+			// Query again to get the latest information
+			resource_id := fmt.Sprintf("%v.%v", serial_number, config_id)
+			if latest, get_diags := getEntitlementFromId(resource_id, m); !get_diags.HasError() {
+				entitlement = latest
+			}
+		}
+		result_entitlements = appendEntitlement(result_entitlements, entitlement)
+	}
+	d.Set("reaped_entitlements", result_entitlements)
+	return diags
+}
+
+func isOrphanedEntitlement(entitlement map[string]interface{}, pattern *regexp.Regexp, live_tasks []string, older_than_days int) bool {
+	description, _ := entitlement["description"].(string)
+	if description == "" || !pattern.MatchString(description) {
+		return false
+	}
+	if contains(live_tasks, description) {
+		return false
+	}
+	// FortiFlex doesn't record when a task claimed the entitlement, so this is the age
+	// of the entitlement, measured from startDate. It doesn't protect recent claims.
+	if older_than_days > 0 {
+		start_date, ok := entitlement["startDate"].(string)
+		if !ok {
+			return false
+		}
+		t, err := tryParseISO8601(start_date)
+		if err != nil {
+			return false
+		}
+		if time.Since(t) < time.Duration(older_than_days)*24*time.Hour {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package fortiflexvm
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestIsOrphanedEntitlement(t *testing.T) {
+	pattern := regexp.MustCompile("^fortiflexvm-claim-")
+	days_ago := func(days int) string {
+		return time.Now().UTC().AddDate(0, 0, -days).Format("2006-01-02T15:04:05")
+	}
+	cases := []struct {
+		name            string
+		description     interface{}
+		start_date      interface{}
+		live_tasks      []string
+		older_than_days int
+		want            bool
+	}{
+		{"claimed", "fortiflexvm-claim-0123", days_ago(1), nil, 0, true},
+		{"no description", nil, days_ago(1), nil, 0, false},
+		{"empty description", "", days_ago(1), nil, 0, false},
+		{"other description", "web server", days_ago(1), nil, 0, false},
+		{"live task", "fortiflexvm-claim-0123", days_ago(1), []string{"fortiflexvm-claim-0123"}, 0, false},
+		{"other live task", "fortiflexvm-claim-0123", days_ago(1), []string{"fortiflexvm-claim-4567"}, 0, true},
+		{"older than days", "fortiflexvm-claim-0123", days_ago(10), nil, 7, true},
+		{"newer than days", "fortiflexvm-claim-0123", days_ago(3), nil, 7, false},
+		{"no start date", "fortiflexvm-claim-0123", nil, nil, 7, false},
+		{"no start date without age", "fortiflexvm-claim-0123", nil, nil, 0, true},
+		{"invalid start date", "fortiflexvm-claim-0123", "yesterday", nil, 7, false},
+		{"date only start date", "fortiflexvm-claim-0123", days_ago(10)[:10], nil, 7, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			entitlement := map[string]interface{}{"serialNumber": "FGVMMLTM00000001"}
+			if c.description != nil {
+				entitlement["description"] = c.description
+			}
+			if c.start_date != nil {
+				entitlement["startDate"] = c.start_date
+			}
+			if got := isOrphanedEntitlement(entitlement, pattern, c.live_tasks, c.older_than_days); got != c.want {
+				t.Errorf("isOrphanedEntitlement(%v, %v, %v) = %v, want %v", entitlement, c.live_tasks, c.older_than_days, got, c.want)
+			}
+		})
+	}
+}
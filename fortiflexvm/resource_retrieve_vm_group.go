@@ -8,6 +8,7 @@ package fortiflexvm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,6 +22,7 @@ func resourceRetrieveVMGroup() *schema.Resource {
 		ReadContext:   resourceRetrieveVMGroupRead,
 		UpdateContext: resourceRetrieveVMGroupUpdate,
 		DeleteContext: resourceRetrieveVMGroupDelete,
+		CustomizeDiff: customizeDiffTokenStorage,
 		Schema: map[string]*schema.Schema{
 			"task_name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -59,6 +61,8 @@ func resourceRetrieveVMGroup() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"token_storage": tokenStorageSchema(),
+			"pgp_key":       pgpKeySchema(),
 			"entitlements": &schema.Schema{
 				Type:     schema.TypeList,
 				Computed: true,
@@ -92,10 +96,9 @@ func resourceRetrieveVMGroup() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"token": &schema.Schema{
-							Type:     schema.TypeString,
-							Computed: true,
-						},
+						"token":           entitlementTokenSchema(),
+						"encrypted_token": encryptedTokenSchema(),
+						"token_sha256":    tokenSha256Schema(),
 						"token_status": &schema.Schema{
 							Type:     schema.TypeString,
 							Computed: true,
@@ -110,8 +113,13 @@ func resourceRetrieveVMGroup() *schema.Resource {
 func resourceRetrieveVMGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	count_number := d.Get("count_num").(int)
 	result_entitlements, found_number, diags := retrieveStoppedEntitlements(count_number, d, m)
+	if err := storeEntitlementTokens(d, result_entitlements, nil); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
 	if diags.HasError() {
-		return diags
+		// Release the entitlements retrieved before the error
+		d.Set("entitlements", result_entitlements)
+		return append(diags, resourceRetrieveVMGroupDelete(ctx, d, m)...)
 	}
 	d.Set("entitlements", result_entitlements)
 	report_error := d.Get("require_exact_count").(bool)
@@ -132,6 +140,12 @@ func resourceRetrieveVMGroupCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceRetrieveVMGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return refreshRetrieveVMGroup(d, m, d.Get("entitlements").([]interface{}))
+}
+
+// refreshRetrieveVMGroup reads the entitlements of the group again. The encrypted
+// tokens of 'previous' are kept, see storeEntitlementTokens.
+func refreshRetrieveVMGroup(d *schema.ResourceData, m interface{}, previous []interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	count_number := d.Get("count_num").(int)
 	result_entitlements := make([]map[string]interface{}, 0, count_number)
@@ -145,6 +159,9 @@ func resourceRetrieveVMGroupRead(ctx context.Context, d *schema.ResourceData, m
 		entitlement, diags = getEntitlementFromId(resource_id, m)
 		result_entitlements = appendEntitlement(result_entitlements, entitlement)
 	}
+	if err := storeEntitlementTokens(d, result_entitlements, previous); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
 	d.Set("entitlements", result_entitlements)
 	return diags
 }
@@ -155,13 +172,20 @@ func resourceRetrieveVMGroupUpdate(ctx context.Context, d *schema.ResourceData,
 	local_entitlements := d.Get("entitlements").([]interface{})
 	local_num := len(local_entitlements)
 	if want_num > local_num {
-		result_entitlements, found_number, diags := retrieveStoppedEntitlements(want_num-local_num, d, m)
-		if diags.HasError() {
-			return diags
+		result_entitlements, found_number, retrieve_diags := retrieveStoppedEntitlements(want_num-local_num, d, m)
+		diags = append(diags, retrieve_diags...)
+		if err := storeEntitlementTokens(d, result_entitlements, nil); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
 		}
 		for _, entitlement := range result_entitlements {
 			local_entitlements = append(local_entitlements, entitlement)
 		}
+		if diags.HasError() {
+			// Keep the entitlements retrieved before the error
+			d.Set("entitlements", local_entitlements)
+			d.Set("count_num", local_num+found_number)
+			return diags
+		}
 		d.Set("entitlements", local_entitlements)
 		d.Set("count_num", local_num+found_number)
 	} else if want_num < local_num {
@@ -173,7 +197,7 @@ func resourceRetrieveVMGroupUpdate(ctx context.Context, d *schema.ResourceData,
 			} else {
 				serial_number := entitlement["serial_number"].(string)
 				config_id := entitlement["config_id"].(int)
-				diags = removeEntitlement(serial_number, config_id, d, m)
+				diags = removeEntitlement(serial_number, config_id, true, d.Get("refresh_token_when_destroy").(bool), m)
 				if diags.HasError() {
 					return diags
 				}
@@ -182,6 +206,10 @@ func resourceRetrieveVMGroupUpdate(ctx context.Context, d *schema.ResourceData,
 		d.Set("entitlements", result_entitlements)
 		d.Set("count_num", len(result_entitlements))
 	}
+	if d.HasChanges("token_storage", "pgp_key") {
+		// Read the tokens again to store them in the new way
+		diags = append(diags, refreshRetrieveVMGroup(d, m, nil)...)
+	}
 	return diags
 }
 
@@ -192,7 +220,7 @@ func resourceRetrieveVMGroupDelete(ctx context.Context, d *schema.ResourceData,
 		entitlement := item.(map[string]interface{})
 		serial_number := entitlement["serial_number"].(string)
 		config_id := entitlement["config_id"].(int)
-		diags = removeEntitlement(serial_number, config_id, d, m)
+		diags = removeEntitlement(serial_number, config_id, true, d.Get("refresh_token_when_destroy").(bool), m)
 		if diags.HasError() {
 			return diags
 		}
@@ -201,7 +229,7 @@ func resourceRetrieveVMGroupDelete(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
-func removeEntitlement(serial_number string, config_id int, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func removeEntitlement(serial_number string, config_id int, stop bool, refresh_token bool, m interface{}) diag.Diagnostics {
 	c := m.(*FortiClient).Client
 	var diags diag.Diagnostics
 	var err error
@@ -215,12 +243,14 @@ func removeEntitlement(serial_number string, config_id int, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 	// Stop
-	_, err = changeVMStatus(serial_number, "stop", m)
-	if err != nil {
-		return diag.FromErr(err)
+	if stop {
+		_, _, err = changeVMStatus(serial_number, config_id, "stop", m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	// Refresh token
-	if d.Get("refresh_token_when_destroy").(bool) {
+	if refresh_token {
 		request_obj = make(map[string]interface{})
 		request_obj["serialNumber"] = serial_number
 		_, err = c.UpdateVmUpdateRegenerateToken(&request_obj)
@@ -291,7 +321,18 @@ func retrieveStoppedEntitlements(want_num int, d *schema.ResourceData, m interfa
 				continue
 			}
 			// Use this entitlement
-			entitlement, err = changeVMStatus(serial_number, "reactivate", m)
+			var warnings []string
+			entitlement, warnings, err = changeVMStatus(serial_number, d.Get("config_id").(int), "reactivate", m)
+			diags = append(diags, pointsBudgetWarnings(warnings)...)
+			if errors.Is(err, ErrPointsBudgetExceeded) {
+				// Release this entitlement, the next ones would exceed the budget as well
+				request_obj = make(map[string]interface{})
+				request_obj["serialNumber"] = serial_number
+				request_obj["configId"] = config_id
+				request_obj["description"] = ""
+				c.UpdateVmUpdate(&request_obj)
+				return result_entitlements, found_number, append(diags, diag.FromErr(err)...)
+			}
 			if err != nil {
 				continue
 			}
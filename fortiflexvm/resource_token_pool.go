@@ -0,0 +1,292 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Keep a number of unused VM entitlements ready in a configuration and folder.
+
+package fortiflexvm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTokenPool() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTokenPoolCreate,
+		ReadContext:   resourceTokenPoolRead,
+		UpdateContext: resourceTokenPoolUpdate,
+		DeleteContext: resourceTokenPoolDelete,
+		CustomizeDiff: resourceTokenPoolCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"config_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"folder_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"min_available": &schema.Schema{
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: checkInputValidInt("min_available", 0, math.MaxInt32),
+			},
+			"max_total": &schema.Schema{
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: checkInputValidInt("max_total", 0, math.MaxInt32),
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"stop_available_when_destroy": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"available_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"used_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"total_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"available_serial_numbers": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// TOKEN_POOL_TAG_PREFIX starts the description written into the entitlements
+// created by fortiflexvm_token_pool. It is followed by the ID of the pool.
+const TOKEN_POOL_TAG_PREFIX = "fortiflexvm-pool-"
+
+// tokenPoolState is the entitlements of a pool, found by tokenPoolRead.
+type tokenPoolState struct {
+	available []map[string]interface{}
+	used      int
+}
+
+func (s tokenPoolState) total() int {
+	return len(s.available) + s.used
+}
+
+func resourceTokenPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(fmt.Sprintf("%v.%v", d.Get("config_id"), d.Get("folder_path")))
+	return reconcileTokenPool(d, m)
+}
+
+func resourceTokenPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pool, err := tokenPoolRead(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setTokenPoolState(d, pool)
+	return nil
+}
+
+func resourceTokenPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return reconcileTokenPool(d, m)
+}
+
+func resourceTokenPoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("stop_available_when_destroy").(bool) {
+		pool, err := tokenPoolRead(d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		config_id := d.Get("config_id").(int)
+		for _, entitlement := range pool.available {
+			serial_number := fmt.Sprintf("%v", entitlement["serialNumber"])
+			if _, _, err := changeVMStatus(serial_number, config_id, "stop", m); err != nil {
+				return diag.FromErr(fmt.Errorf("error stopping entitlement %v: %v", serial_number, err))
+			}
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceTokenPoolCustomizeDiff plans an update when the refreshed pool does not
+// meet min_available or max_total, so the next apply reconciles it.
+func resourceTokenPoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	min_available := d.Get("min_available").(int)
+	max_total := d.Get("max_total").(int)
+	if max_total > 0 && min_available > max_total {
+		return fmt.Errorf("min_available (%v) can't be greater than max_total (%v)", min_available, max_total)
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	create_num, stop_num := tokenPoolChanges(d.Get("available_count").(int), d.Get("total_count").(int), min_available, max_total)
+	if create_num == 0 && stop_num == 0 {
+		return nil
+	}
+	for _, key := range []string{"available_count", "used_count", "total_count", "available_serial_numbers"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tokenPoolChanges returns the number of entitlements to create and to stop.
+// max_total wins over min_available, and only unused entitlements are stopped.
+func tokenPoolChanges(available int, total int, min_available int, max_total int) (int, int) {
+	create_num := min_available - available
+	stop_num := available - min_available
+	if max_total > 0 {
+		if room := max_total - total; create_num > room {
+			create_num = room
+		}
+		if over := total - max_total; stop_num < over {
+			stop_num = over
+		}
+	}
+	if create_num < 0 {
+		create_num = 0
+	}
+	if stop_num < 0 {
+		stop_num = 0
+	}
+	if stop_num > available {
+		stop_num = available
+	}
+	return create_num, stop_num
+}
+
+func reconcileTokenPool(d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := m.(*FortiClient).Client
+	config_id := d.Get("config_id").(int)
+
+	pool, err := tokenPoolRead(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	create_num, stop_num := tokenPoolChanges(len(pool.available), pool.total(), d.Get("min_available").(int), d.Get("max_total").(int))
+
+	// Create the shortfall
+	if create_num > 0 {
+		warnings, err := m.(*FortiClient).CheckPointsBudget(config_id, create_num)
+		diags = append(diags, pointsBudgetWarnings(warnings)...)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		request_obj := make(map[string]interface{})
+		request_obj["configId"] = config_id
+		request_obj["count"] = create_num
+		if v, ok := d.GetOk("folder_path"); ok {
+			request_obj["folderPath"] = v
+		}
+		request_obj["description"] = tokenPoolDescription(d.Id(), d.Get("description").(string))
+		if _, err := c.CreateEntitlementsVM(&request_obj); err != nil {
+			m.(*FortiClient).ReleasePointsBudget(config_id, create_num)
+			return append(diags, diag.FromErr(fmt.Errorf("error creating %v entitlement(s): %v", create_num, err))...)
+		}
+	}
+
+	// Stop the excess, the most recent entitlements first
+	for i := 0; i < stop_num; i++ {
+		entitlement := pool.available[len(pool.available)-1-i]
+		serial_number := fmt.Sprintf("%v", entitlement["serialNumber"])
+		if _, _, err := changeVMStatus(serial_number, config_id, "stop", m); err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("error stopping entitlement %v: %v", serial_number, err))...)
+		}
+	}
+
+	if create_num > 0 || stop_num > 0 {
+		pool, err = tokenPoolRead(d, m)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	setTokenPoolState(d, pool)
+	return diags
+}
+
+// tokenPoolDescription returns the description of the entitlements created by
+// the pool: its tag, followed by the description set by the user.
+func tokenPoolDescription(id string, description string) string {
+	if description == "" {
+		return TOKEN_POOL_TAG_PREFIX + id
+	}
+	return TOKEN_POOL_TAG_PREFIX + id + " " + description
+}
+
+// isTokenPoolEntitlement returns true if the description of the entitlement has
+// the tag of the pool. Entitlements claimed by fortiflexvm_entitlement_token
+// lose the tag, so they leave the pool.
+func isTokenPoolEntitlement(entitlement map[string]interface{}, id string) bool {
+	description, _ := entitlement["description"].(string)
+	tag := TOKEN_POOL_TAG_PREFIX + id
+	return description == tag || strings.HasPrefix(description, tag+" ")
+}
+
+// tokenPoolRead lists the entitlements of the pool. Only the entitlements created
+// by the pool are part of it, and STOPPED and EXPIRED ones are not.
+func tokenPoolRead(d *schema.ResourceData, m interface{}) (tokenPoolState, error) {
+	c := m.(*FortiClient).Client
+	pool := tokenPoolState{available: make([]map[string]interface{}, 0)}
+	folder_path := strings.TrimSuffix(d.Get("folder_path").(string), "/")
+
+	request_obj := make(map[string]interface{})
+	request_obj["configId"] = d.Get("config_id").(int)
+	o, err := c.ReadEntitlementsList(&request_obj)
+	if err != nil {
+		return pool, fmt.Errorf("error describing EntitlementsList: %v", err)
+	}
+	entitlement_list, _ := o["entitlements"].([]interface{})
+	for _, item := range entitlement_list {
+		entitlement, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !isTokenPoolEntitlement(entitlement, d.Id()) {
+			continue
+		}
+		if folder_path != "" && strings.TrimSuffix(entitlementListValue(entitlement, "folder_path"), "/") != folder_path {
+			continue
+		}
+		switch entitlementListValue(entitlement, "status") {
+		case "STOPPED", "EXPIRED":
+			continue
+		case "PENDING":
+			if entitlementListValue(entitlement, "token_status") == "NOTUSED" {
+				pool.available = append(pool.available, entitlement)
+				continue
+			}
+		}
+		pool.used += 1
+	}
+	return pool, nil
+}
+
+func setTokenPoolState(d *schema.ResourceData, pool tokenPoolState) {
+	serial_numbers := make([]string, 0, len(pool.available))
+	for _, entitlement := range pool.available {
+		serial_numbers = append(serial_numbers, fmt.Sprintf("%v", entitlement["serialNumber"]))
+	}
+	d.Set("available_count", len(pool.available))
+	d.Set("used_count", pool.used)
+	d.Set("total_count", pool.total())
+	d.Set("available_serial_numbers", serial_numbers)
+}
@@ -0,0 +1,64 @@
+package fortiflexvm
+
+import "testing"
+
+func TestTokenPoolChanges(t *testing.T) {
+	cases := []struct {
+		name          string
+		available     int
+		total         int
+		min_available int
+		max_total     int
+		create_num    int
+		stop_num      int
+	}{
+		{"balanced", 3, 5, 3, 0, 0, 0},
+		{"shortfall", 1, 4, 3, 0, 2, 0},
+		{"excess", 5, 6, 3, 0, 0, 2},
+		{"empty pool", 0, 0, 2, 0, 2, 0},
+		{"shortfall capped by max_total", 1, 9, 3, 10, 1, 0},
+		{"max_total reached", 0, 10, 3, 10, 0, 0},
+		{"over max_total", 4, 12, 3, 10, 0, 2},
+		{"over max_total with few available", 1, 12, 0, 10, 0, 1},
+		{"over max_total without available", 0, 12, 3, 10, 0, 0},
+		{"min_available zero", 2, 2, 0, 0, 0, 2},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			create_num, stop_num := tokenPoolChanges(c.available, c.total, c.min_available, c.max_total)
+			if create_num != c.create_num || stop_num != c.stop_num {
+				t.Errorf("tokenPoolChanges(%v, %v, %v, %v) = (%v, %v), want (%v, %v)",
+					c.available, c.total, c.min_available, c.max_total, create_num, stop_num, c.create_num, c.stop_num)
+			}
+		})
+	}
+}
+
+func TestIsTokenPoolEntitlement(t *testing.T) {
+	id := "1234.My Assets/pool"
+	cases := []struct {
+		name        string
+		description interface{}
+		want        bool
+	}{
+		{"tag", tokenPoolDescription(id, ""), true},
+		{"tag and description", tokenPoolDescription(id, "autoscaling"), true},
+		{"no description", nil, false},
+		{"empty description", "", false},
+		{"other description", "web servers", false},
+		{"claimed", "fortiflexvm-claim-0123456789abcdef", false},
+		{"other pool", tokenPoolDescription("1234.My Assets/pool2", ""), false},
+		{"other pool with the same prefix", TOKEN_POOL_TAG_PREFIX + id + "2", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			entitlement := map[string]interface{}{"serialNumber": "FGVMMLTM00000001"}
+			if c.description != nil {
+				entitlement["description"] = c.description
+			}
+			if got := isTokenPoolEntitlement(entitlement, id); got != c.want {
+				t.Errorf("isTokenPoolEntitlement(%q) = %v, want %v", c.description, got, c.want)
+			}
+		})
+	}
+}
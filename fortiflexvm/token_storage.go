@@ -0,0 +1,201 @@
+// Copyright 2026 Fortinet, Inc. All rights reserved.
+// Author: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+// Documentation: Xing Li (@lix-fortinet), Xinwei Du (@dux-fortinet), Hongbin Lu (@fgtdev-hblu)
+
+// Description: Keep VM tokens out of the state, or encrypt them with a PGP public key.
+
+package fortiflexvm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TOKEN_STORAGE_MODES are the values of token_storage:
+// "plain" saves the token in the state, "omit" doesn't save it, and "pgp" saves
+// it in encrypted_token, encrypted with pgp_key. An empty value means "plain".
+var TOKEN_STORAGE_MODES = []string{"plain", "omit", "pgp"}
+
+func tokenStorageSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: checkInputValidString("token_storage", TOKEN_STORAGE_MODES),
+	}
+}
+
+func pgpKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+}
+
+// CheckTokenStorage checks that pgp_key is a usable public key if token_storage is "pgp".
+func CheckTokenStorage(token_storage string, pgp_key string) error {
+	if token_storage != "pgp" {
+		return nil
+	}
+	if pgp_key == "" {
+		return fmt.Errorf("pgp_key is required when token_storage is \"pgp\"")
+	}
+	if _, err := readPGPKey(pgp_key); err != nil {
+		return fmt.Errorf("invalid pgp_key: %v", err)
+	}
+	return nil
+}
+
+// StoreToken returns the token and the encrypted token to save in the state.
+func StoreToken(token string, token_storage string, pgp_key string) (string, string, error) {
+	switch token_storage {
+	case "omit":
+		return "", "", nil
+	case "pgp":
+		if token == "" {
+			return "", "", nil
+		}
+		encrypted_token, err := EncryptToken(token, pgp_key)
+		return "", encrypted_token, err
+	}
+	return token, "", nil
+}
+
+// EncryptToken encrypts the token for the PGP public key pgp_key, either ASCII armored or
+// base64 encoded. The result is the base64 encoded binary message, it can be decrypted with
+// "base64 --decode | gpg --decrypt".
+func EncryptToken(token string, pgp_key string) (string, error) {
+	entity, err := readPGPKey(pgp_key)
+	if err != nil {
+		return "", fmt.Errorf("error reading pgp_key: %v", err)
+	}
+	var buffer bytes.Buffer
+	writer, err := openpgp.Encrypt(&buffer, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("error encrypting token: %v", err)
+	}
+	if _, err = writer.Write([]byte(token)); err != nil {
+		return "", fmt.Errorf("error encrypting token: %v", err)
+	}
+	if err = writer.Close(); err != nil {
+		return "", fmt.Errorf("error encrypting token: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+func readPGPKey(pgp_key string) (*openpgp.Entity, error) {
+	pgp_key = strings.TrimSpace(pgp_key)
+	var key_bytes []byte
+	if strings.HasPrefix(pgp_key, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		block, err := armor.Decode(strings.NewReader(pgp_key))
+		if err != nil {
+			return nil, err
+		}
+		var buffer bytes.Buffer
+		if _, err = buffer.ReadFrom(block.Body); err != nil {
+			return nil, err
+		}
+		key_bytes = buffer.Bytes()
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(pgp_key)
+		if err != nil {
+			return nil, fmt.Errorf("the key is neither ASCII armored nor base64 encoded: %v", err)
+		}
+		key_bytes = decoded
+	}
+	entities, err := openpgp.ReadKeyRing(bytes.NewReader(key_bytes))
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("expected one public key, got %v", len(entities))
+	}
+	return entities[0], nil
+}
+
+// entitlementTokenSchema is the token of the entitlements in lists.
+func entitlementTokenSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Computed:  true,
+		Sensitive: true,
+	}
+}
+
+func encryptedTokenSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// tokenSha256Schema is the hash of the token and pgp_key of encrypted_token.
+func tokenSha256Schema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// TokenSha256 returns the hash of the token and pgp_key, it tells whether an
+// encrypted token must be encrypted again.
+func TokenSha256(token string, pgp_key string) string {
+	sum := sha256.Sum256([]byte(token + "\n" + pgp_key))
+	return hex.EncodeToString(sum[:])
+}
+
+// storeEntitlementTokens applies token_storage of d to the flattened entitlements.
+// With "pgp", the encrypted token of the same serial number in 'previous' is kept
+// while the token and pgp_key don't change, so the state doesn't change on every refresh.
+func storeEntitlementTokens(d *schema.ResourceData, entitlements []map[string]interface{}, previous []interface{}) error {
+	token_storage := d.Get("token_storage").(string)
+	pgp_key := d.Get("pgp_key").(string)
+	if err := CheckTokenStorage(token_storage, pgp_key); err != nil {
+		return err
+	}
+	previous_encrypted := make(map[string]map[string]interface{})
+	for _, item := range previous {
+		if entitlement, ok := item.(map[string]interface{}); ok {
+			if v, _ := entitlement["encrypted_token"].(string); v != "" {
+				previous_encrypted[fmt.Sprintf("%v", entitlement["serial_number"])] = entitlement
+			}
+		}
+	}
+	for _, entitlement := range entitlements {
+		token := ""
+		if v, ok := entitlement["token"]; ok && v != nil {
+			token = fmt.Sprintf("%v", v)
+		}
+		token_sha256 := ""
+		if token_storage == "pgp" && token != "" {
+			token_sha256 = TokenSha256(token, pgp_key)
+			if v, ok := previous_encrypted[fmt.Sprintf("%v", entitlement["serial_number"])]; ok && v["token_sha256"] == token_sha256 {
+				entitlement["token"] = ""
+				entitlement["encrypted_token"] = v["encrypted_token"]
+				entitlement["token_sha256"] = token_sha256
+				continue
+			}
+		}
+		token, encrypted_token, err := StoreToken(token, token_storage, pgp_key)
+		if err != nil {
+			return fmt.Errorf("error storing the token of entitlement %v: %v", entitlement["serial_number"], err)
+		}
+		entitlement["token"] = token
+		entitlement["encrypted_token"] = encrypted_token
+		entitlement["token_sha256"] = token_sha256
+	}
+	return nil
+}
+
+// customizeDiffTokenStorage checks token_storage and pgp_key at plan time.
+func customizeDiffTokenStorage(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return CheckTokenStorage(d.Get("token_storage").(string), d.Get("pgp_key").(string))
+}
@@ -0,0 +1,189 @@
+package fortiflexvm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testPGPKey returns a new key pair and its armored public key.
+func testPGPKey(t *testing.T) (*openpgp.Entity, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	writer, err := armor.Encode(&buffer, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = entity.Serialize(writer); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+	return entity, buffer.String()
+}
+
+func decryptTestToken(t *testing.T, entity *openpgp.Entity, encrypted_token string) string {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(encrypted_token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := openpgp.ReadMessage(bytes.NewReader(data), openpgp.EntityList{entity}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := io.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(token)
+}
+
+func TestStoreToken(t *testing.T) {
+	entity, pgp_key := testPGPKey(t)
+	cases := []struct {
+		name          string
+		token         string
+		token_storage string
+		pgp_key       string
+		want_token    string
+		encrypted     bool
+		err           bool
+	}{
+		{"default", "TOKEN", "", "", "TOKEN", false, false},
+		{"plain", "TOKEN", "plain", "", "TOKEN", false, false},
+		{"omit", "TOKEN", "omit", "", "", false, false},
+		{"pgp", "TOKEN", "pgp", pgp_key, "", true, false},
+		{"pgp without token", "", "pgp", pgp_key, "", false, false},
+		{"pgp invalid key", "TOKEN", "pgp", "not a key", "", false, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			token, encrypted_token, err := StoreToken(c.token, c.token_storage, c.pgp_key)
+			if (err != nil) != c.err {
+				t.Fatalf("StoreToken() error = %v, want error %v", err, c.err)
+			}
+			if token != c.want_token {
+				t.Errorf("StoreToken() token = %q, want %q", token, c.want_token)
+			}
+			if (encrypted_token != "") != c.encrypted {
+				t.Fatalf("StoreToken() encrypted_token = %q, want encrypted %v", encrypted_token, c.encrypted)
+			}
+			if c.encrypted {
+				if got := decryptTestToken(t, entity, encrypted_token); got != c.token {
+					t.Errorf("decrypted token = %q, want %q", got, c.token)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckTokenStorage(t *testing.T) {
+	_, pgp_key := testPGPKey(t)
+	cases := []struct {
+		name          string
+		token_storage string
+		pgp_key       string
+		err           bool
+	}{
+		{"plain", "plain", "", false},
+		{"omit with key", "omit", "not a key", false},
+		{"pgp", "pgp", pgp_key, false},
+		{"pgp without key", "pgp", "", true},
+		{"pgp invalid key", "pgp", "not a key", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := CheckTokenStorage(c.token_storage, c.pgp_key); (err != nil) != c.err {
+				t.Errorf("CheckTokenStorage(%q) error = %v, want error %v", c.token_storage, err, c.err)
+			}
+		})
+	}
+}
+
+func TestStoreEntitlementTokens(t *testing.T) {
+	entity, pgp_key := testPGPKey(t)
+	_, other_pgp_key := testPGPKey(t)
+	previous_encrypted, err := EncryptToken("TOKEN", pgp_key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := []interface{}{
+		map[string]interface{}{
+			"serial_number":   "FGVMMLTM00000001",
+			"encrypted_token": previous_encrypted,
+			"token_sha256":    TokenSha256("TOKEN", pgp_key),
+		},
+	}
+	cases := []struct {
+		name          string
+		token_storage string
+		pgp_key       string
+		token         interface{}
+		previous      []interface{}
+		want_token    string
+		kept          bool
+		encrypted     bool
+	}{
+		{"plain", "plain", "", "TOKEN", previous, "TOKEN", false, false},
+		{"omit", "omit", "", "TOKEN", previous, "", false, false},
+		{"pgp without token", "pgp", pgp_key, nil, previous, "", false, false},
+		{"pgp keeps the encrypted token", "pgp", pgp_key, "TOKEN", previous, "", true, true},
+		{"pgp without previous", "pgp", pgp_key, "TOKEN", nil, "", false, true},
+		{"pgp new token", "pgp", pgp_key, "NEW_TOKEN", previous, "", false, true},
+		{"pgp new key", "pgp", other_pgp_key, "TOKEN", previous, "", false, true},
+	}
+	resource_schema := map[string]*schema.Schema{
+		"token_storage": tokenStorageSchema(),
+		"pgp_key":       pgpKeySchema(),
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resource_schema, map[string]interface{}{
+				"token_storage": c.token_storage,
+				"pgp_key":       c.pgp_key,
+			})
+			entitlement := map[string]interface{}{"serial_number": "FGVMMLTM00000001", "token": c.token}
+			if err := storeEntitlementTokens(d, []map[string]interface{}{entitlement}, c.previous); err != nil {
+				t.Fatal(err)
+			}
+			if entitlement["token"] != c.want_token {
+				t.Errorf("token = %q, want %q", entitlement["token"], c.want_token)
+			}
+			encrypted_token, _ := entitlement["encrypted_token"].(string)
+			if (encrypted_token != "") != c.encrypted {
+				t.Fatalf("encrypted_token = %q, want encrypted %v", encrypted_token, c.encrypted)
+			}
+			if (encrypted_token == previous_encrypted) != c.kept {
+				t.Errorf("encrypted_token kept = %v, want %v", encrypted_token == previous_encrypted, c.kept)
+			}
+			if c.encrypted && c.pgp_key == pgp_key {
+				if got := decryptTestToken(t, entity, encrypted_token); got != c.token {
+					t.Errorf("decrypted token = %q, want %q", got, c.token)
+				}
+				if entitlement["token_sha256"] != TokenSha256(c.token.(string), c.pgp_key) {
+					t.Errorf("token_sha256 = %v, want the hash of the token and pgp_key", entitlement["token_sha256"])
+				}
+			}
+		})
+	}
+}
+
+func TestStoreEntitlementTokensInvalidKey(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"token_storage": tokenStorageSchema(),
+		"pgp_key":       pgpKeySchema(),
+	}, map[string]interface{}{"token_storage": "pgp", "pgp_key": "not a key"})
+	entitlement := map[string]interface{}{"serial_number": "FGVMMLTM00000001", "token": "TOKEN"}
+	if err := storeEntitlementTokens(d, []map[string]interface{}{entitlement}, nil); err == nil {
+		t.Errorf("storeEntitlementTokens() with an invalid pgp_key returned no error")
+	}
+}
@@ -0,0 +1,109 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ action.Action = &actionConfigStatus{}
+var _ action.ActionWithConfigure = &actionConfigStatus{}
+
+// actionConfigStatus disables or enables one configuration.
+type actionConfigStatus struct {
+	fortiClient *fortiflexvm.FortiClient
+	typeName    string
+	op          string
+	description string
+}
+
+func NewActionConfigDisable() action.Action {
+	return &actionConfigStatus{
+		typeName:    "fortiflexvm_config_disable",
+		op:          "disable",
+		description: "Disable one configuration. No new entitlement can be created with a DISABLED configuration.",
+	}
+}
+
+func NewActionConfigEnable() action.Action {
+	return &actionConfigStatus{
+		typeName:    "fortiflexvm_config_enable",
+		op:          "enable",
+		description: "Enable one DISABLED configuration.",
+	}
+}
+
+func (a *actionConfigStatus) Metadata(ctx context.Context, request action.MetadataRequest, response *action.MetadataResponse) {
+	response.TypeName = a.typeName
+}
+
+func (a *actionConfigStatus) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.fortiClient = client
+}
+
+func (a *actionConfigStatus) Schema(ctx context.Context, request action.SchemaRequest, response *action.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: a.description,
+		Attributes: map[string]schema.Attribute{
+			"config_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "The ID of the configuration.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *actionConfigStatus) Invoke(ctx context.Context, request action.InvokeRequest, response *action.InvokeResponse) {
+	var model actionConfigStatusModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &model)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	config_id := model.ConfigID.ValueInt64()
+	response.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Sending %v request for configuration %v", a.op, config_id),
+	})
+	obj := make(map[string]interface{})
+	obj["id"] = config_id
+	o, err := a.fortiClient.Client.UpdateConfigStatus(&obj, a.op)
+	if err != nil {
+		response.Diagnostics.AddError(
+			fmt.Sprintf("Unable to %v configuration %v", a.op, config_id),
+			err.Error(),
+		)
+		return
+	}
+	response.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Configuration %v status: %v", config_id, o["status"]),
+	})
+}
+
+type actionConfigStatusModel struct {
+	ConfigID types.Int64 `tfsdk:"config_id"`
+}
@@ -0,0 +1,81 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ action.Action = &actionEntitlementRegenerateToken{}
+var _ action.ActionWithConfigure = &actionEntitlementRegenerateToken{}
+
+type actionEntitlementRegenerateToken struct {
+	fortiClient *fortiflexvm.FortiClient
+}
+
+func NewActionEntitlementRegenerateToken() action.Action {
+	return &actionEntitlementRegenerateToken{}
+}
+
+func (a *actionEntitlementRegenerateToken) Metadata(ctx context.Context, request action.MetadataRequest, response *action.MetadataResponse) {
+	response.TypeName = "fortiflexvm_entitlement_regenerate_token"
+}
+
+func (a *actionEntitlementRegenerateToken) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.fortiClient = client
+}
+
+func (a *actionEntitlementRegenerateToken) Schema(ctx context.Context, request action.SchemaRequest, response *action.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Regenerate the token of one VM entitlement. The old token can't be used anymore.",
+		Attributes: map[string]schema.Attribute{
+			"serial_number": schema.StringAttribute{
+				Required:    true,
+				Description: "The serial number of the VM entitlement.",
+			},
+		},
+	}
+}
+
+func (a *actionEntitlementRegenerateToken) Invoke(ctx context.Context, request action.InvokeRequest, response *action.InvokeResponse) {
+	var model actionEntitlementModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &model)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	serial_number := model.SerialNumber.ValueString()
+	response.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Regenerating token for entitlement %v", serial_number),
+	})
+	o, err := regenerateEntitlementToken(a.fortiClient.Client, serial_number)
+	if err != nil {
+		response.Diagnostics.AddError(
+			fmt.Sprintf("Unable to regenerate token for entitlement %v", serial_number),
+			err.Error(),
+		)
+		return
+	}
+	response.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Entitlement %v token status: %v", serial_number, o["tokenStatus"]),
+	})
+}
@@ -0,0 +1,111 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ action.Action = &actionEntitlementStatus{}
+var _ action.ActionWithConfigure = &actionEntitlementStatus{}
+
+// actionEntitlementStatus stops or reactivates one entitlement.
+type actionEntitlementStatus struct {
+	fortiClient *fortiflexvm.FortiClient
+	typeName    string
+	op          string
+	description string
+}
+
+func NewActionEntitlementStop() action.Action {
+	return &actionEntitlementStatus{
+		typeName:    "fortiflexvm_entitlement_stop",
+		op:          "stop",
+		description: "Stop one entitlement. A STOPPED entitlement is not charged.",
+	}
+}
+
+func NewActionEntitlementReactivate() action.Action {
+	return &actionEntitlementStatus{
+		typeName:    "fortiflexvm_entitlement_reactivate",
+		op:          "reactivate",
+		description: "Reactivate one STOPPED entitlement.",
+	}
+}
+
+func (a *actionEntitlementStatus) Metadata(ctx context.Context, request action.MetadataRequest, response *action.MetadataResponse) {
+	response.TypeName = a.typeName
+}
+
+func (a *actionEntitlementStatus) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.fortiClient = client
+}
+
+func (a *actionEntitlementStatus) Schema(ctx context.Context, request action.SchemaRequest, response *action.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: a.description,
+		Attributes: map[string]schema.Attribute{
+			"serial_number": schema.StringAttribute{
+				Required:    true,
+				Description: "The serial number of the entitlement.",
+			},
+		},
+	}
+}
+
+func (a *actionEntitlementStatus) Invoke(ctx context.Context, request action.InvokeRequest, response *action.InvokeResponse) {
+	var model actionEntitlementModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &model)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	serial_number := model.SerialNumber.ValueString()
+	if a.op == "reactivate" {
+		response.Diagnostics.Append(pointsBudgetDiagnostics(a.fortiClient.CheckPointsBudgetForSerial(serial_number))...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+	response.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Sending %v request for entitlement %v", a.op, serial_number),
+	})
+	o, err := changeEntitlementStatus(a.fortiClient.Client, serial_number, a.op)
+	if err != nil {
+		if a.op == "reactivate" {
+			a.fortiClient.ReleasePointsBudgetForSerial(serial_number)
+		}
+		response.Diagnostics.AddError(
+			fmt.Sprintf("Unable to %v entitlement %v", a.op, serial_number),
+			err.Error(),
+		)
+		return
+	}
+	response.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Entitlement %v status: %v", serial_number, o["status"]),
+	})
+}
+
+type actionEntitlementModel struct {
+	SerialNumber types.String `tfsdk:"serial_number"`
+}
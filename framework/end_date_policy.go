@@ -0,0 +1,161 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+// DEFAULT_RENEWAL_WINDOW_DAYS is the default renewal_window_days of end_date_policy.
+const DEFAULT_RENEWAL_WINDOW_DAYS = 30
+
+// endDatePolicyBlock is the 'end_date_policy' block of entitlement resources.
+func endDatePolicyBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Compute end_date at plan time instead of setting it. The end date of an existing entitlement is only extended when it is inside the renewal window.",
+		Attributes: map[string]schema.Attribute{
+			"rolling_days": schema.Int64Attribute{
+				Required:    true,
+				Description: "The end date is today (UTC) plus this number of days. It must be larger than renewal_window_days.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"align_to": schema.StringAttribute{
+				Optional:    true,
+				Description: "Move the end date to the last day of its month with \"month_end\".",
+				Validators: []validator.String{
+					stringvalidator.OneOf("month_end"),
+				},
+			},
+			"cap_at_program_end": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Use the end date of the configuration's program if the computed end date is after it. Default is true.",
+			},
+			"renewal_window_days": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Extend the end date of an existing entitlement when it is at most this number of days away. Default is 30.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+type endDatePolicyModel struct {
+	RollingDays       types.Int64  `tfsdk:"rolling_days"`
+	AlignTo           types.String `tfsdk:"align_to"`
+	CapAtProgramEnd   types.Bool   `tfsdk:"cap_at_program_end"`
+	RenewalWindowDays types.Int64  `tfsdk:"renewal_window_days"`
+}
+
+// desiredEndDate returns the end date required by the policy. program_end is
+// ignored if it is zero or cap_at_program_end is false.
+func (p endDatePolicyModel) desiredEndDate(today time.Time, program_end time.Time) time.Time {
+	end_date := today.AddDate(0, 0, int(p.RollingDays.ValueInt64()))
+	if p.AlignTo.ValueString() == "month_end" {
+		end_date = time.Date(end_date.Year(), end_date.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	}
+	if (p.CapAtProgramEnd.IsNull() || p.CapAtProgramEnd.ValueBool()) && !program_end.IsZero() && end_date.After(program_end) {
+		end_date = program_end
+	}
+	return end_date
+}
+
+// renewalWindowDays returns renewal_window_days, or its default value.
+func (p endDatePolicyModel) renewalWindowDays() int64 {
+	if !p.RenewalWindowDays.IsNull() && !p.RenewalWindowDays.IsUnknown() {
+		return p.RenewalWindowDays.ValueInt64()
+	}
+	return DEFAULT_RENEWAL_WINDOW_DAYS
+}
+
+// inRenewalWindow reports whether the current end date is close enough to today to be extended.
+func (p endDatePolicyModel) inRenewalWindow(today time.Time, current time.Time) bool {
+	return !current.After(today.AddDate(0, 0, int(p.renewalWindowDays())))
+}
+
+// modifyEndDatePolicyPlan sets the planned end_date from end_date_policy. A new entitlement
+// gets the end date of the policy, an existing one keeps its end date until it is inside the
+// renewal window, then it is extended. The end date is never shortened.
+func modifyEndDatePolicyPlan(ctx context.Context, client *fortiflexvm.FortiClient, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	var policy *endDatePolicyModel
+	var config_id types.Int64
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("end_date_policy"), &policy)...)
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("config_id"), &config_id)...)
+	if response.Diagnostics.HasError() || policy == nil || policy.RollingDays.IsUnknown() || policy.RenewalWindowDays.IsUnknown() {
+		return
+	}
+	// Otherwise the extended end date is still inside the window, and every plan extends it again
+	if policy.RollingDays.ValueInt64() <= policy.renewalWindowDays() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("end_date_policy").AtName("rolling_days"),
+			"Invalid end_date_policy",
+			fmt.Sprintf("rolling_days (%v) must be larger than renewal_window_days (%v).", policy.RollingDays.ValueInt64(), policy.renewalWindowDays()),
+		)
+		return
+	}
+	if client == nil || config_id.IsUnknown() {
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	var current endDateValue
+	if !request.State.Raw.IsNull() {
+		response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("end_date"), &current)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+	current_end_date, current_err := tryParseISO8601(current.ValueString())
+	if current_err == nil && !policy.inRenewalWindow(today, current_end_date) {
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("end_date"), current)...)
+		return
+	}
+
+	var program_end time.Time
+	if policy.CapAtProgramEnd.IsNull() || policy.CapAtProgramEnd.ValueBool() {
+		_, program_end_date, err := client.ProgramEndDate(int(config_id.ValueInt64()))
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("end_date_policy"),
+				"Unable to apply end_date_policy",
+				fmt.Sprintf("Unable to find the end date of the program of configuration %v: %v", config_id.ValueInt64(), err),
+			)
+			return
+		}
+		if program_end, err = tryParseISO8601(program_end_date); err != nil {
+			program_end = time.Time{}
+		}
+	}
+	desired := policy.desiredEndDate(today, program_end)
+
+	if current.IsNull() || current.IsUnknown() {
+		// A new entitlement
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("end_date"), endDateValue{StringValue: types.StringValue(desired.Format(END_DATE_FORMAT))})...)
+		return
+	}
+	if current_err != nil || !desired.After(current_end_date) {
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("end_date"), current)...)
+		return
+	}
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("end_date"), endDateValue{StringValue: types.StringValue(desired.Format(END_DATE_FORMAT))})...)
+	response.Diagnostics.AddAttributeWarning(
+		path.Root("end_date"),
+		"End date extension",
+		fmt.Sprintf("end_date will be extended from %v to %v by end_date_policy.", current.ValueString(), desired.Format(END_DATE_FORMAT)),
+	)
+}
@@ -0,0 +1,120 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+// ENTITLEMENT_STATUSES are the statuses of an entitlement in FortiFlex.
+var ENTITLEMENT_STATUSES = []string{"PENDING", "ACTIVE", "STOPPED", "EXPIRED"}
+
+// entitlementStatusOperations are the status changes that can be requested,
+// and the API operation that performs each of them.
+var entitlementStatusOperations = map[[2]string]string{
+	{"PENDING", "STOPPED"}: "stop",
+	{"ACTIVE", "STOPPED"}:  "stop",
+	{"STOPPED", "ACTIVE"}:  "reactivate",
+}
+
+// entitlementStatusOperation returns the API operation that changes the status of an
+// entitlement from 'from' to 'to', "" if there is nothing to do, or an error if the
+// change can't be requested.
+func entitlementStatusOperation(from string, to string) (string, error) {
+	if from == to {
+		return "", nil
+	}
+	if operation, ok := entitlementStatusOperations[[2]string{from, to}]; ok {
+		return operation, nil
+	}
+	switch {
+	case from == "EXPIRED":
+		return "", fmt.Errorf("the entitlement is EXPIRED, its status can't be changed to %v. Extend end_date of the configuration's program or create a new entitlement", to)
+	case to == "EXPIRED":
+		return "", fmt.Errorf("the status can't be changed to EXPIRED, an entitlement expires at its end_date. Change end_date instead")
+	case to == "PENDING":
+		return "", fmt.Errorf("the status can't be changed from %v to PENDING, only new entitlements are PENDING", from)
+	case from == "PENDING" && to == "ACTIVE":
+		return "", fmt.Errorf("the status can't be changed from PENDING to ACTIVE. The entitlement becomes ACTIVE once it is used, e.g. once a VM uses its token")
+	}
+	return "", fmt.Errorf("the status can't be changed from %v to %v", from, to)
+}
+
+// modifyEntitlementStatusPlan rejects status changes that can't be performed and
+// shows the operation the apply will send. 'initial_status' is the status of a new
+// entitlement, "" if it is unknown.
+func modifyEntitlementStatusPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse, initial_status string) {
+	if request.Plan.Raw.IsNull() {
+		// Destroy
+		return
+	}
+	var planned, current, serial_number types.String
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("status"), &planned)...)
+	if response.Diagnostics.HasError() || !isSet(planned) {
+		return
+	}
+	if request.State.Raw.IsNull() {
+		// A new entitlement, unless it takes over an existing serial number
+		response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("serial_number"), &serial_number)...)
+		if response.Diagnostics.HasError() || initial_status == "" || !serial_number.IsNull() {
+			return
+		}
+		current = types.StringValue(initial_status)
+	} else {
+		response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("status"), &current)...)
+	}
+	if response.Diagnostics.HasError() || !isSet(current) {
+		return
+	}
+
+	operation, err := entitlementStatusOperation(current.ValueString(), planned.ValueString())
+	if err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("status"), "Invalid entitlement status change", err.Error())
+		return
+	}
+	if operation != "" {
+		response.Diagnostics.AddAttributeWarning(
+			path.Root("status"),
+			"Entitlement status change",
+			fmt.Sprintf("The status will be changed from %v to %v by the '%v' operation.", current.ValueString(), planned.ValueString(), operation),
+		)
+	}
+}
+
+// changeEntitlementStatusByPlan moves the entitlement from current_status to the planned
+// status. Reactivation checks the points budget first. It returns the response of the
+// operation, or nil if there is nothing to do.
+func changeEntitlementStatusByPlan(client *fortiflexvm.FortiClient, serial_number string, config_id int64, current_status string, planned types.String) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !isSet(planned) {
+		return nil, diags
+	}
+	operation, err := entitlementStatusOperation(current_status, planned.ValueString())
+	if err != nil {
+		diags.AddError("Unable to change entitlement status", err.Error())
+		return nil, diags
+	}
+	if operation == "" {
+		return nil, diags
+	}
+	if operation == "reactivate" {
+		diags.Append(checkPointsBudget(client, config_id, 1)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	target_entitlement, err := changeEntitlementStatus(client.Client, serial_number, operation)
+	if err != nil {
+		if operation == "reactivate" {
+			client.ReleasePointsBudget(int(config_id), 1)
+		}
+		diags.AddError("Unable to change entitlement status", err.Error())
+		return nil, diags
+	}
+	return target_entitlement, diags
+}
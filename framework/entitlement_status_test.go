@@ -0,0 +1,61 @@
+package framework
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEntitlementStatusOperation(t *testing.T) {
+	cases := []struct {
+		from      string
+		to        string
+		operation string
+		err       string
+	}{
+		{"PENDING", "PENDING", "", ""},
+		{"PENDING", "ACTIVE", "", "once it is used"},
+		{"PENDING", "STOPPED", "stop", ""},
+		{"PENDING", "EXPIRED", "", "expires at its end_date"},
+		{"ACTIVE", "PENDING", "", "only new entitlements are PENDING"},
+		{"ACTIVE", "ACTIVE", "", ""},
+		{"ACTIVE", "STOPPED", "stop", ""},
+		{"ACTIVE", "EXPIRED", "", "expires at its end_date"},
+		{"STOPPED", "PENDING", "", "only new entitlements are PENDING"},
+		{"STOPPED", "ACTIVE", "reactivate", ""},
+		{"STOPPED", "STOPPED", "", ""},
+		{"STOPPED", "EXPIRED", "", "expires at its end_date"},
+		{"EXPIRED", "PENDING", "", "the entitlement is EXPIRED"},
+		{"EXPIRED", "ACTIVE", "", "the entitlement is EXPIRED"},
+		{"EXPIRED", "STOPPED", "", "the entitlement is EXPIRED"},
+		{"EXPIRED", "EXPIRED", "", ""},
+		{"ACTIVE", "DELETED", "", "from ACTIVE to DELETED"},
+	}
+	for _, c := range cases {
+		t.Run(c.from+"_to_"+c.to, func(t *testing.T) {
+			operation, err := entitlementStatusOperation(c.from, c.to)
+			if operation != c.operation {
+				t.Errorf("entitlementStatusOperation(%v, %v) = %q, want %q", c.from, c.to, operation, c.operation)
+			}
+			if c.err == "" {
+				if err != nil {
+					t.Errorf("entitlementStatusOperation(%v, %v) returned error: %v", c.from, c.to, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("entitlementStatusOperation(%v, %v) error = %v, want an error containing %q", c.from, c.to, err, c.err)
+			}
+		})
+	}
+}
+
+func TestEntitlementStatusOperationsCoverStatuses(t *testing.T) {
+	for transition := range entitlementStatusOperations {
+		for _, status := range transition {
+			if !slices.Contains(ENTITLEMENT_STATUSES, status) {
+				t.Errorf("transition %v uses unknown status %v", transition, status)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ ephemeral.EphemeralResource = &ephemeralAccessToken{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralAccessToken{}
+
+type ephemeralAccessToken struct {
+	fortiClient *fortiflexvm.FortiClient
+}
+
+func NewEphemeralAccessToken() ephemeral.EphemeralResource {
+	return &ephemeralAccessToken{}
+}
+
+func (e *ephemeralAccessToken) Metadata(ctx context.Context, request ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = "fortiflexvm_access_token"
+}
+
+func (e *ephemeralAccessToken) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.fortiClient = client
+}
+
+func (e *ephemeralAccessToken) Schema(ctx context.Context, request ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Get a new FortiFlex API access token with the credentials of the provider.",
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The bearer token of the FortiFlex API.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The expiry time of the token in RFC 3339 format. Empty if FortiFlex doesn't return it.",
+			},
+			"expires_in": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of seconds before the token expires.",
+			},
+		},
+	}
+}
+
+func (e *ephemeralAccessToken) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	var model ephemeralAccessTokenModel
+
+	token, expiry, err := e.fortiClient.Client.GenerateAccessToken()
+	if err != nil {
+		response.Diagnostics.AddError("Unable to get access token", err.Error())
+		return
+	}
+
+	model.AccessToken = types.StringValue(token)
+	model.ExpiresAt = types.StringValue("")
+	model.ExpiresIn = types.Int64Value(0)
+	if !expiry.IsZero() {
+		model.ExpiresAt = types.StringValue(expiry.UTC().Format(time.RFC3339))
+		model.ExpiresIn = types.Int64Value(int64(time.Until(expiry).Seconds()))
+	}
+	response.Diagnostics.Append(response.Result.Set(ctx, model)...)
+}
+
+type ephemeralAccessTokenModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	ExpiresIn   types.Int64  `tfsdk:"expires_in"`
+}
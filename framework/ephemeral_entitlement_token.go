@@ -0,0 +1,411 @@
+package framework
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+	forticlient "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
+)
+
+var _ ephemeral.EphemeralResource = &ephemeralEntitlementToken{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ephemeralEntitlementToken{}
+var _ ephemeral.EphemeralResourceWithClose = &ephemeralEntitlementToken{}
+
+// ENTITLEMENT_CLAIM_PREFIX starts the description written into the entitlements
+// claimed by fortiflexvm_entitlement_token.
+const ENTITLEMENT_CLAIM_PREFIX = "fortiflexvm-claim-"
+
+// entitlementClaimLock serializes the claims of this provider process. Claims of
+// other processes are detected with the description, see claimEntitlement.
+var entitlementClaimLock sync.Mutex
+
+type ephemeralEntitlementToken struct {
+	fortiClient *fortiflexvm.FortiClient
+}
+
+func NewEphemeralEntitlementToken() ephemeral.EphemeralResource {
+	return &ephemeralEntitlementToken{}
+}
+
+func (e *ephemeralEntitlementToken) Metadata(ctx context.Context, request ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = "fortiflexvm_entitlement_token"
+}
+
+func (e *ephemeralEntitlementToken) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.fortiClient = client
+}
+
+func (e *ephemeralEntitlementToken) Schema(ctx context.Context, request ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Claim one entitlement with an unused token, and return its token.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The account ID. Used with folder_path.",
+			},
+			"config_id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Claim an entitlement of this configuration.",
+			},
+			"folder_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Claim an entitlement in this folder. Ignored if config_id is set.",
+			},
+			"status": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "The status of the entitlements that can be claimed. Default is [\"PENDING\", \"STOPPED\"].",
+			},
+			"regenerate_token": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Regenerate the token of the claimed entitlement before returning it.",
+			},
+			"preempt_interval": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The seconds to wait before checking that the claim of an entitlement is not overwritten by another run. Default is 1.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"on_close": schema.StringAttribute{
+				Optional: true,
+				Description: "What to do when Terraform closes this ephemeral resource: " +
+					"\"keep\" (default) keeps the entitlement and its claim, \"release\" restores its previous status and removes the claim, \"stop\" stops it and removes the claim.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("keep", "release", "stop"),
+				},
+			},
+			"serial_number": schema.StringAttribute{
+				Computed:    true,
+				Description: "The serial number of the claimed entitlement.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token of the claimed entitlement.",
+			},
+		},
+	}
+}
+
+func (e *ephemeralEntitlementToken) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	var model ephemeralEntitlementTokenModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &model)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	c := e.fortiClient.Client
+
+	if model.ConfigID.ValueInt64() == 0 && !isSet(model.FolderPath) {
+		response.Diagnostics.AddError("Either config_id or folder_path is required", "")
+		return
+	}
+	status_list := []string{"PENDING", "STOPPED"}
+	if len(model.Status.Elements()) > 0 {
+		status_list = make([]string, 0)
+		response.Diagnostics.Append(model.Status.ElementsAs(ctx, &status_list, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	preempt_interval := 1.0
+	if !model.PreemptInterval.IsNull() {
+		preempt_interval = model.PreemptInterval.ValueFloat64()
+	}
+
+	// Hold the lock until the entitlement is claimed
+	entitlementClaimLock.Lock()
+	defer entitlementClaimLock.Unlock()
+
+	candidates := make([]map[string]interface{}, 0)
+	if model.ConfigID.ValueInt64() != 0 {
+		request_obj := make(map[string]interface{})
+		request_obj["configId"] = model.ConfigID.ValueInt64()
+		o, err := c.ReadEntitlementsList(&request_obj)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to read entitlement list", err.Error())
+			return
+		}
+		entitlements, _ := o["entitlements"].([]interface{})
+		for _, ent := range entitlements {
+			ent_map, ok := ent.(map[string]interface{})
+			if !ok || ent_map["tokenStatus"] != "NOTUSED" || !isUnclaimedEntitlement(ent_map) {
+				continue
+			}
+			if slices.Contains(status_list, fmt.Sprintf("%v", ent_map["status"])) {
+				candidates = append(candidates, ent_map)
+			}
+		}
+	} else {
+		// The API only returns the next unused token of the folder
+		request_obj := make(map[string]interface{})
+		request_obj["folderPath"] = model.FolderPath.ValueString()
+		request_obj["status"] = status_list
+		if v := model.AccountID.ValueInt64(); v != 0 {
+			request_obj["accountId"] = v
+		}
+		o, err := c.ReadGroupsNexttoken(&request_obj)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to get the next token", err.Error())
+			return
+		}
+		var next_entitlement map[string]interface{}
+		switch v := o["entitlements"].(type) {
+		case map[string]interface{}:
+			next_entitlement = v
+		case []interface{}:
+			if len(v) > 0 {
+				next_entitlement, _ = v[0].(map[string]interface{})
+			}
+		}
+		if next_entitlement != nil {
+			// Read the description, it is not always returned with the next token
+			next_entitlement, err = readEntitlement(c, fmt.Sprintf("%v", next_entitlement["serialNumber"]), int64FromAPI(next_entitlement["configId"]).ValueInt64())
+			if err != nil {
+				response.Diagnostics.AddError("Unable to read entitlement", err.Error())
+				return
+			}
+			if !isUnclaimedEntitlement(next_entitlement) {
+				response.Diagnostics.AddError(
+					"No unclaimed entitlement",
+					fmt.Sprintf("The next token in %v is already claimed. Use config_id to claim several entitlements.", model.FolderPath.ValueString()),
+				)
+				return
+			}
+			candidates = append(candidates, next_entitlement)
+		}
+	}
+
+	tag, err := newEntitlementClaimTag()
+	if err != nil {
+		response.Diagnostics.AddError("Unable to claim entitlement", err.Error())
+		return
+	}
+	var target_entitlement map[string]interface{}
+	for _, candidate := range candidates {
+		target_entitlement, err = claimEntitlement(ctx, c, candidate, tag, preempt_interval)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to claim entitlement", err.Error())
+			return
+		}
+		if target_entitlement == nil {
+			continue
+		}
+		// The entitlement may have changed before it was claimed
+		if target_entitlement["tokenStatus"] == "NOTUSED" && slices.Contains(status_list, fmt.Sprintf("%v", target_entitlement["status"])) {
+			break
+		}
+		setEntitlementDescription(c, fmt.Sprintf("%v", target_entitlement["serialNumber"]), int64FromAPI(target_entitlement["configId"]).ValueInt64(), "")
+		target_entitlement = nil
+	}
+	if target_entitlement == nil {
+		response.Diagnostics.AddError(
+			"No unclaimed entitlement",
+			fmt.Sprintf("No unclaimed entitlement with an unused token and status in %v.", status_list),
+		)
+		return
+	}
+
+	claim := entitlementTokenClaim{
+		SerialNumber:   fmt.Sprintf("%v", target_entitlement["serialNumber"]),
+		ConfigID:       int64FromAPI(target_entitlement["configId"]).ValueInt64(),
+		PreviousStatus: fmt.Sprintf("%v", target_entitlement["status"]),
+		OnClose:        model.OnClose.ValueString(),
+		Tag:            tag,
+	}
+
+	// A STOPPED entitlement is reactivated, so it is no longer available to others
+	reactivated := false
+	if claim.PreviousStatus == "STOPPED" {
+		response.Diagnostics.Append(checkPointsBudget(e.fortiClient, claim.ConfigID, 1)...)
+		if !response.Diagnostics.HasError() {
+			_, err = changeEntitlementStatus(c, claim.SerialNumber, "reactivate")
+			if err != nil {
+				e.fortiClient.ReleasePointsBudget(int(claim.ConfigID), 1)
+				response.Diagnostics.AddError("Unable to reactivate entitlement", err.Error())
+			}
+		}
+		if response.Diagnostics.HasError() {
+			response.Diagnostics.Append(releaseEntitlementClaim(c, claim, false)...)
+			return
+		}
+		reactivated = true
+	}
+
+	if model.RegenerateToken.ValueBool() {
+		_, err = regenerateEntitlementToken(c, claim.SerialNumber)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to regenerate token", err.Error())
+			response.Diagnostics.Append(releaseEntitlementClaim(c, claim, reactivated)...)
+			return
+		}
+		target_entitlement, err = readEntitlement(c, claim.SerialNumber, claim.ConfigID)
+		if err != nil {
+			response.Diagnostics.AddError("Unable to read entitlement", err.Error())
+			response.Diagnostics.Append(releaseEntitlementClaim(c, claim, reactivated)...)
+			return
+		}
+	}
+
+	claim_json, err := json.Marshal(claim)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to save the claim", err.Error())
+		response.Diagnostics.Append(releaseEntitlementClaim(c, claim, reactivated)...)
+		return
+	}
+	response.Diagnostics.Append(response.Private.SetKey(ctx, "claim", claim_json)...)
+
+	model.ConfigID = types.Int64Value(claim.ConfigID)
+	model.SerialNumber = types.StringValue(claim.SerialNumber)
+	model.Token = stringFromAPI(target_entitlement["token"])
+	response.Diagnostics.Append(response.Result.Set(ctx, model)...)
+}
+
+func (e *ephemeralEntitlementToken) Close(ctx context.Context, request ephemeral.CloseRequest, response *ephemeral.CloseResponse) {
+	claim_json, diags := request.Private.GetKey(ctx, "claim")
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() || claim_json == nil {
+		return
+	}
+	var claim entitlementTokenClaim
+	if err := json.Unmarshal(claim_json, &claim); err != nil {
+		response.Diagnostics.AddError("Unable to read the claim", err.Error())
+		return
+	}
+
+	// With "keep", the entitlement stays claimed: its token has been handed out, it must not be
+	// claimed again. Claims that are no longer used are released by fortiflexvm_entitlements_reaper.
+	if claim.OnClose == "" || claim.OnClose == "keep" {
+		return
+	}
+	var err error
+	if claim.OnClose == "stop" || claim.PreviousStatus == "STOPPED" {
+		_, err = changeEntitlementStatus(e.fortiClient.Client, claim.SerialNumber, "stop")
+	}
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Unable to stop entitlement %v", claim.SerialNumber), err.Error())
+	}
+	// Remove the claim, so the entitlement can be claimed again
+	if err = setEntitlementDescription(e.fortiClient.Client, claim.SerialNumber, claim.ConfigID, ""); err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Unable to remove the claim of entitlement %v", claim.SerialNumber), err.Error())
+	}
+}
+
+// isUnclaimedEntitlement returns true if the entitlement has no description, or
+// only the tag of a fortiflexvm_token_pool. Like fortiflexvm_retrieve_vm_group,
+// entitlements with another description are not claimed.
+func isUnclaimedEntitlement(entitlement map[string]interface{}) bool {
+	description, _ := entitlement["description"].(string)
+	return description == "" || strings.HasPrefix(description, fortiflexvm.TOKEN_POOL_TAG_PREFIX)
+}
+
+func newEntitlementClaimTag() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return ENTITLEMENT_CLAIM_PREFIX + hex.EncodeToString(b), nil
+}
+
+// claimEntitlement writes the tag into the description of the entitlement, waits
+// preempt_interval seconds and reads it again. It returns the entitlement if the
+// tag is still there, or nil if another run has claimed it at the same time.
+func claimEntitlement(ctx context.Context, c *forticlient.FortiSDKClient, entitlement map[string]interface{}, tag string, preempt_interval float64) (map[string]interface{}, error) {
+	serial_number := fmt.Sprintf("%v", entitlement["serialNumber"])
+	config_id := int64FromAPI(entitlement["configId"]).ValueInt64()
+	if err := setEntitlementDescription(c, serial_number, config_id, tag); err != nil {
+		return nil, nil
+	}
+	select {
+	case <-ctx.Done():
+		setEntitlementDescription(c, serial_number, config_id, "")
+		return nil, ctx.Err()
+	case <-time.After(time.Duration(preempt_interval * float64(time.Second))):
+	}
+	claimed, err := readEntitlement(c, serial_number, config_id)
+	if err != nil {
+		return nil, fmt.Errorf("%v\nThe description of entitlement %v may still be %v, clear it to release the entitlement.", err, serial_number, tag)
+	}
+	if claimed["description"] != tag {
+		return nil, nil
+	}
+	return claimed, nil
+}
+
+// releaseEntitlementClaim undoes a claim that can't be completed: the entitlement is
+// stopped again if it has been reactivated, and the tag is removed.
+func releaseEntitlementClaim(c *forticlient.FortiSDKClient, claim entitlementTokenClaim, reactivated bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if reactivated {
+		if _, err := changeEntitlementStatus(c, claim.SerialNumber, "stop"); err != nil {
+			diags.AddError(fmt.Sprintf("Unable to stop entitlement %v", claim.SerialNumber), err.Error())
+		}
+	}
+	if err := setEntitlementDescription(c, claim.SerialNumber, claim.ConfigID, ""); err != nil {
+		diags.AddError(fmt.Sprintf("Unable to remove the claim of entitlement %v", claim.SerialNumber), err.Error())
+	}
+	return diags
+}
+
+func setEntitlementDescription(c *forticlient.FortiSDKClient, serial_number string, config_id int64, description string) error {
+	obj := make(map[string]interface{})
+	obj["serialNumber"] = serial_number
+	obj["configId"] = config_id
+	obj["description"] = description
+	_, err := c.UpdateVmUpdate(&obj)
+	return err
+}
+
+// entitlementTokenClaim is saved in the private data between Open and Close.
+type entitlementTokenClaim struct {
+	SerialNumber   string `json:"serial_number"`
+	ConfigID       int64  `json:"config_id"`
+	PreviousStatus string `json:"previous_status"`
+	OnClose        string `json:"on_close"`
+	Tag            string `json:"tag"`
+}
+
+type ephemeralEntitlementTokenModel struct {
+	AccountID       types.Int64   `tfsdk:"account_id"`
+	ConfigID        types.Int64   `tfsdk:"config_id"`
+	FolderPath      types.String  `tfsdk:"folder_path"`
+	Status          types.List    `tfsdk:"status"`
+	RegenerateToken types.Bool    `tfsdk:"regenerate_token"`
+	PreemptInterval types.Float64 `tfsdk:"preempt_interval"`
+	OnClose         types.String  `tfsdk:"on_close"`
+	SerialNumber    types.String  `tfsdk:"serial_number"`
+	Token           types.String  `tfsdk:"token"`
+}
@@ -2,10 +2,14 @@ package framework
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
 )
@@ -60,6 +64,18 @@ func (e *ephemeralGroupsNexttoken) Schema(ctx context.Context, request ephemeral
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"wait_timeout": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 86400),
+				},
+			},
+			"poll_interval": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3600),
+				},
+			},
 			"token": schema.StringAttribute{
 				Computed:  true,
 				Sensitive: true,
@@ -103,10 +119,23 @@ func (e *ephemeralGroupsNexttoken) Open(ctx context.Context, request ephemeral.O
 			"Either config_id or folder_path is required",
 			"",
 		)
+		return
 	}
 
 	// Send request
-	o, err := c.ReadGroupsNexttoken(&request_obj)
+	wait_timeout := time.Duration(model.WaitTimeout.ValueInt64()) * time.Second
+	poll_interval := 10 * time.Second
+	if v := model.PollInterval.ValueInt64(); v != 0 {
+		poll_interval = time.Duration(v) * time.Second
+	}
+	o, err := fortiflexvm.WaitGroupsNexttoken(ctx, c, request_obj, wait_timeout, poll_interval)
+	if errors.Is(err, fortiflexvm.ErrNoTokenAvailable) {
+		response.Diagnostics.AddError(
+			fortiflexvm.NEXTTOKEN_TIMEOUT_SUMMARY,
+			fortiflexvm.NexttokenTimeoutDetail(request_obj, wait_timeout, err),
+		)
+		return
+	}
 	if err != nil {
 		response.Diagnostics.AddError(
 			fmt.Sprintf("Error to get token: %v", err),
@@ -128,26 +157,23 @@ func (e *ephemeralGroupsNexttoken) Open(ctx context.Context, request ephemeral.O
 }
 
 func ephemeralRefreshObjectGroupsNexttoken(o map[string]interface{}) (string, error) {
-	var entitlement string
-	switch v := o["entitlements"].(type) {
-	case map[string]interface{}:
-		if v, ok := o["entitlements"].(map[string]interface{})["token"]; ok {
-			entitlement = v.(string)
-		}
-	case []interface{}:
-		if v, ok := o["entitlements"].([]interface{})[0].(map[string]interface{})["token"]; ok {
-			entitlement = v.(string)
-		}
-	default:
-		return "", fmt.Errorf("Unsupported type: %T\n", v)
+	entitlements := fortiflexvm.NexttokenEntitlements(o)
+	if len(entitlements) == 0 {
+		return "", fmt.Errorf("the response contains no entitlement, set wait_timeout to wait for an available token")
+	}
+	token, ok := entitlements[0].(map[string]interface{})["token"].(string)
+	if !ok || token == "" {
+		return "", fmt.Errorf("the entitlement %v has no token", entitlements[0].(map[string]interface{})["serialNumber"])
 	}
-	return entitlement, nil
+	return token, nil
 }
 
 type ephemeralGroupsNexttokenModel struct {
-	AccountID  types.Int64  `tfsdk:"account_id"`
-	ConfigID   types.Int64  `tfsdk:"config_id"`
-	FolderPath types.String `tfsdk:"folder_path"`
-	Status     types.List   `tfsdk:"status"`
-	Token      types.String `tfsdk:"token"`
+	AccountID    types.Int64  `tfsdk:"account_id"`
+	ConfigID     types.Int64  `tfsdk:"config_id"`
+	FolderPath   types.String `tfsdk:"folder_path"`
+	Status       types.List   `tfsdk:"status"`
+	WaitTimeout  types.Int64  `tfsdk:"wait_timeout"`
+	PollInterval types.Int64  `tfsdk:"poll_interval"`
+	Token        types.String `tfsdk:"token"`
 }
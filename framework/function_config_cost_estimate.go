@@ -0,0 +1,156 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+	forticlient "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
+)
+
+var _ function.Function = &functionConfigCostEstimate{}
+
+type functionConfigCostEstimate struct{}
+
+func NewFunctionConfigCostEstimate() function.Function {
+	return &functionConfigCostEstimate{}
+}
+
+func (f *functionConfigCostEstimate) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "config_cost_estimate"
+}
+
+func (f *functionConfigCostEstimate) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary: "Estimate the points used by one entitlement of a configuration.",
+		Description: "Return the estimated daily and monthly points of one entitlement from the pricing table " +
+			"built into the provider. The parameters use the arguments of the product block of fortiflexvm_config, " +
+			"e.g. { cpu_size = \"2\", service_pkg = \"ATP\" } for FGT_VM_Bundle.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "product_type",
+				Description: "The product type, e.g. FGT_VM_Bundle.",
+			},
+			function.DynamicParameter{
+				Name:        "parameters",
+				Description: "An object with the arguments of the product block.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"daily_points":    types.Float64Type,
+				"monthly_points":  types.Float64Type,
+				"pricing_version": types.StringType,
+			},
+		},
+	}
+}
+
+func (f *functionConfigCostEstimate) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var product_type string
+	var parameters types.Dynamic
+
+	response.Error = request.Arguments.Get(ctx, &product_type, &parameters)
+	if response.Error != nil {
+		return
+	}
+
+	parameter_map, err := costParametersFromValue(parameters.UnderlyingValue())
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	table, err := forticlient.EmbeddedPricingTable()
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+	daily_points, err := table.EstimateDailyPoints(product_type, parameter_map)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+	response.Error = response.Result.Set(ctx, functionConfigCostEstimateModel{
+		DailyPoints:    types.Float64Value(daily_points),
+		MonthlyPoints:  types.Float64Value(daily_points * fortiflexvm.DAYS_PER_MONTH),
+		PricingVersion: types.StringValue(table.Version),
+	})
+}
+
+// costParametersFromValue converts an object or a map of the product block
+// arguments to the parameters of the pricing table.
+func costParametersFromValue(v attr.Value) (map[string]interface{}, error) {
+	var elements map[string]attr.Value
+	switch value := v.(type) {
+	case types.Object:
+		elements = value.Attributes()
+	case types.Map:
+		elements = value.Elements()
+	case nil:
+		return map[string]interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("parameters should be an object, got: %v", v.Type(context.Background()))
+	}
+
+	parameters := make(map[string]interface{})
+	for name, element := range elements {
+		if element.IsNull() {
+			continue
+		}
+		if element.IsUnknown() {
+			return nil, fmt.Errorf("%v is unknown", name)
+		}
+		switch value := element.(type) {
+		case types.String:
+			parameters[name] = value.ValueString()
+		case types.Number:
+			parameters[name], _ = value.ValueBigFloat().Float64()
+		case types.Int64:
+			parameters[name] = value.ValueInt64()
+		case types.Float64:
+			parameters[name] = value.ValueFloat64()
+		case types.List:
+			list, err := costParameterList(name, value.Elements())
+			if err != nil {
+				return nil, err
+			}
+			parameters[name] = list
+		case types.Tuple:
+			list, err := costParameterList(name, value.Elements())
+			if err != nil {
+				return nil, err
+			}
+			parameters[name] = list
+		case types.Set:
+			list, err := costParameterList(name, value.Elements())
+			if err != nil {
+				return nil, err
+			}
+			parameters[name] = list
+		default:
+			return nil, fmt.Errorf("%v should be a string, a number or a list of strings", name)
+		}
+	}
+	return parameters, nil
+}
+
+func costParameterList(name string, elements []attr.Value) ([]string, error) {
+	list := make([]string, 0, len(elements))
+	for _, element := range elements {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			return nil, fmt.Errorf("%v should be a list of strings", name)
+		}
+		list = append(list, value.ValueString())
+	}
+	return list, nil
+}
+
+type functionConfigCostEstimateModel struct {
+	DailyPoints    types.Float64 `tfsdk:"daily_points"`
+	MonthlyPoints  types.Float64 `tfsdk:"monthly_points"`
+	PricingVersion types.String  `tfsdk:"pricing_version"`
+}
@@ -0,0 +1,51 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &functionNormalizeEndDate{}
+
+type functionNormalizeEndDate struct{}
+
+func NewFunctionNormalizeEndDate() function.Function {
+	return &functionNormalizeEndDate{}
+}
+
+func (f *functionNormalizeEndDate) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "normalize_end_date"
+}
+
+func (f *functionNormalizeEndDate) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary: "Normalize an end date.",
+		Description: "Convert an ISO 8601 date, e.g. 2025-12-31 or 2025-12-31T00:00, to the format returned by FortiFlex: " +
+			"YYYY-MM-DDThh:mm:ss in UTC.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "end_date",
+				Description: "The end date in ISO 8601 format.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *functionNormalizeEndDate) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var end_date string
+
+	response.Error = request.Arguments.Get(ctx, &end_date)
+	if response.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeEndDate(end_date)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to parse %v, please use ISO 8601 format: %v", end_date, err))
+		return
+	}
+	response.Error = response.Result.Set(ctx, normalized)
+}
@@ -0,0 +1,71 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ function.Function = &functionParameterName{}
+
+type functionParameterName struct{}
+
+func NewFunctionParameterName() function.Function {
+	return &functionParameterName{}
+}
+
+func (f *functionParameterName) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "parameter_name"
+}
+
+func (f *functionParameterName) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary: "Decode a configuration parameter ID.",
+		Description: "Return the product type block, the argument name and the data type of a configuration parameter ID, " +
+			"e.g. fgt_vm_bundle, cpu_size and string for 1.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:        "parameter_id",
+				Description: "The ID of a configuration parameter.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"product_type": types.StringType,
+				"name":         types.StringType,
+				"data_type":    types.StringType,
+			},
+		},
+	}
+}
+
+func (f *functionParameterName) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var parameter_id int64
+
+	response.Error = request.Arguments.Get(ctx, &parameter_id)
+	if response.Error != nil {
+		return
+	}
+
+	product_type, name, data_type := fortiflexvm.ConfigParameterName(int(parameter_id))
+	if name == "" {
+		response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown parameter ID: %v", parameter_id))
+		return
+	}
+	response.Error = response.Result.Set(ctx, functionParameterNameModel{
+		ProductType: types.StringValue(product_type),
+		Name:        types.StringValue(name),
+		DataType:    types.StringValue(strings.ToLower(data_type)),
+	})
+}
+
+type functionParameterNameModel struct {
+	ProductType types.String `tfsdk:"product_type"`
+	Name        types.String `tfsdk:"name"`
+	DataType    types.String `tfsdk:"data_type"`
+}
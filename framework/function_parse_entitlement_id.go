@@ -0,0 +1,56 @@
+package framework
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &functionParseEntitlementID{}
+
+type functionParseEntitlementID struct{}
+
+func NewFunctionParseEntitlementID() function.Function {
+	return &functionParseEntitlementID{}
+}
+
+func (f *functionParseEntitlementID) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "parse_entitlement_id"
+}
+
+func (f *functionParseEntitlementID) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Parse the ID of an entitlement resource.",
+		Description: "Split the ID 'serial_number.config_id' of an entitlement resource into serial_number and config_id.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The ID of an entitlement resource, e.g. FGVMMLTM12345678.123.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"serial_number": types.StringType,
+				"config_id":     types.Int64Type,
+			},
+		},
+	}
+}
+
+func (f *functionParseEntitlementID) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var id string
+
+	response.Error = request.Arguments.Get(ctx, &id)
+	if response.Error != nil {
+		return
+	}
+
+	identity, err := newEntitlementIdentity(id)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	response.Error = response.Result.Set(ctx, identity)
+}
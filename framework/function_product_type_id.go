@@ -0,0 +1,51 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ function.Function = &functionProductTypeID{}
+
+type functionProductTypeID struct{}
+
+func NewFunctionProductTypeID() function.Function {
+	return &functionProductTypeID{}
+}
+
+func (f *functionProductTypeID) Metadata(ctx context.Context, request function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "product_type_id"
+}
+
+func (f *functionProductTypeID) Definition(ctx context.Context, request function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:     "Get the ID of a product type.",
+		Description: "Return the FortiFlex ID of a product type name, e.g. 1 for FGT_VM_Bundle.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "product_type",
+				Description: "The product type name, e.g. FGT_VM_Bundle.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *functionProductTypeID) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var product_type string
+
+	response.Error = request.Arguments.Get(ctx, &product_type)
+	if response.Error != nil {
+		return
+	}
+
+	product_type_id := fortiflexvm.ProductTypeId(product_type)
+	if product_type_id == 0 {
+		response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown product type: %v", product_type))
+		return
+	}
+	response.Error = response.Result.Set(ctx, int64(product_type_id))
+}
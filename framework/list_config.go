@@ -0,0 +1,170 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ list.ListResource = &listConfig{}
+var _ list.ListResourceWithConfigure = &listConfig{}
+var _ list.ListResourceWithRawV5Schemas = &listConfig{}
+
+// listConfig lists configurations. fortiflexvm_config is a SDKv2 resource, its
+// schemas are given by RawV5Schemas.
+type listConfig struct {
+	fortiClient *fortiflexvm.FortiClient
+}
+
+func NewListConfig() list.ListResource {
+	return &listConfig{}
+}
+
+func (l *listConfig) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "fortiflexvm_config"
+}
+
+func (l *listConfig) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	l.fortiClient = client
+}
+
+func (l *listConfig) RawV5Schemas(ctx context.Context, request list.RawV5SchemaRequest, response *list.RawV5SchemaResponse) {
+	r := fortiflexvm.ConfigResource()
+	response.ProtoV5Schema = r.ProtoSchema(ctx)()
+	response.ProtoV5IdentitySchema = r.ProtoIdentitySchema(ctx)()
+}
+
+func (l *listConfig) ListResourceConfigSchema(ctx context.Context, request list.ListResourceSchemaRequest, response *list.ListResourceSchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "List configurations of one program.",
+		Attributes: map[string]schema.Attribute{
+			"program_serial_number": schema.StringAttribute{
+				Required:    true,
+				Description: "The serial number of a program.",
+			},
+			"account_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The account ID.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the configurations with this name.",
+			},
+			"product_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the configurations of this product type, e.g. FGT_VM_Bundle.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the configurations with this status.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("ACTIVE", "DISABLED"),
+				},
+			},
+		},
+	}
+}
+
+func (l *listConfig) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	var config listConfigModel
+	var diags diag.Diagnostics
+
+	diags.Append(request.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	request_obj := make(map[string]interface{})
+	request_obj["programSerialNumber"] = config.ProgramSerialNumber.ValueString()
+	if !config.AccountID.IsNull() {
+		request_obj["accountId"] = config.AccountID.ValueInt64()
+	}
+	o, err := l.fortiClient.Client.ReadConfigsList(&request_obj)
+	if err != nil {
+		diags.AddError("Unable to read configuration list", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	configs, _ := o["configs"].([]interface{})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, conf := range configs {
+			conf_map, ok := conf.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if isSet(config.Name) && fmt.Sprintf("%v", conf_map["name"]) != config.Name.ValueString() {
+				continue
+			}
+			if isSet(config.Status) && fmt.Sprintf("%v", conf_map["status"]) != config.Status.ValueString() {
+				continue
+			}
+			if isSet(config.ProductType) {
+				product_type_id := 0
+				if pt, ok := conf_map["productType"].(map[string]interface{}); ok {
+					product_type_id = int(int64FromAPI(pt["id"]).ValueInt64())
+				}
+				if fortiflexvm.ProductTypeId(config.ProductType.ValueString()) != product_type_id {
+					continue
+				}
+			}
+
+			result := request.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("%v (%v)", conf_map["name"], conf_map["id"])
+			d, err := fortiflexvm.ConfigResourceData(conf_map)
+			if err != nil {
+				result.Diagnostics.AddError("Unable to read configuration", err.Error())
+			} else {
+				identity, err := d.TfTypeIdentityState()
+				if err != nil {
+					result.Diagnostics.AddError("Unable to convert configuration identity", err.Error())
+				} else {
+					result.Identity.Raw = *identity
+				}
+				if request.IncludeResource {
+					state, err := d.TfTypeResourceState()
+					if err != nil {
+						result.Diagnostics.AddError("Unable to convert configuration", err.Error())
+					} else {
+						result.Resource.Raw = *state
+					}
+				}
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+type listConfigModel struct {
+	ProgramSerialNumber types.String `tfsdk:"program_serial_number"`
+	AccountID           types.Int64  `tfsdk:"account_id"`
+	Name                types.String `tfsdk:"name"`
+	ProductType         types.String `tfsdk:"product_type"`
+	Status              types.String `tfsdk:"status"`
+}
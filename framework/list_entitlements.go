@@ -0,0 +1,252 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ list.ListResource = &listEntitlements{}
+var _ list.ListResourceWithConfigure = &listEntitlements{}
+
+// listEntitlements lists the entitlements of one kind (VM, hardware or cloud).
+// The kind is decided by the product type ID of the configuration:
+// VM below 100, hardware from 100 to 199, cloud from 200.
+type listEntitlements struct {
+	fortiClient *fortiflexvm.FortiClient
+	typeName    string
+	kind        string
+	isKind      func(product_type_id int) bool
+	newModel    func(o map[string]interface{}) any
+}
+
+func NewListEntitlementsVM() list.ListResource {
+	return &listEntitlements{
+		typeName: "fortiflexvm_entitlements_vm",
+		kind:     "VM",
+		isKind:   func(id int) bool { return id > 0 && id < 100 },
+		newModel: func(o map[string]interface{}) any {
+			m := resourceEntitlementsVMModel{
+				SkipPending:             types.BoolValue(false),
+				RefreshTokenWhenDestroy: types.BoolValue(false),
+			}
+			m.refresh(o)
+			return m
+		},
+	}
+}
+
+func NewListEntitlementsHW() list.ListResource {
+	return &listEntitlements{
+		typeName: "fortiflexvm_entitlements_hardware",
+		kind:     "hardware",
+		isKind:   func(id int) bool { return id >= 100 && id < 200 },
+		newModel: func(o map[string]interface{}) any {
+			m := resourceEntitlementsHWModel{}
+			m.refresh(o)
+			return m
+		},
+	}
+}
+
+func NewListEntitlementsCloud() list.ListResource {
+	return &listEntitlements{
+		typeName: "fortiflexvm_entitlements_cloud",
+		kind:     "cloud",
+		isKind:   func(id int) bool { return id >= 200 },
+		newModel: func(o map[string]interface{}) any {
+			m := resourceEntitlementsCloudModel{}
+			m.refresh(o)
+			return m
+		},
+	}
+}
+
+func (l *listEntitlements) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = l.typeName
+}
+
+func (l *listEntitlements) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	l.fortiClient = client
+}
+
+func (l *listEntitlements) ListResourceConfigSchema(ctx context.Context, request list.ListResourceSchemaRequest, response *list.ListResourceSchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: fmt.Sprintf("List %v entitlements of one configuration or one program. "+
+			"Either config_id or (account_id + program_serial_number) is required.", l.kind),
+		Attributes: map[string]schema.Attribute{
+			"config_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The ID of a configuration.",
+			},
+			"account_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The account ID.",
+			},
+			"program_serial_number": schema.StringAttribute{
+				Optional:    true,
+				Description: "The serial number of a program. Required by product_type.",
+			},
+			"product_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the entitlements of this product type, e.g. FGT_VM_Bundle.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the entitlements with this description.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the entitlements with this status.",
+			},
+		},
+	}
+}
+
+func (l *listEntitlements) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	var config listEntitlementsModel
+	var diags diag.Diagnostics
+
+	diags.Append(request.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	c := l.fortiClient.Client
+
+	config_id := config.ConfigID.ValueInt64()
+	account_id := config.AccountID.ValueInt64()
+	program_serial_number := config.ProgramSerialNumber.ValueString()
+	if config_id == 0 && (account_id == 0 || program_serial_number == "") {
+		diags.AddError("Missing argument", "Either config_id or (account_id + program_serial_number) should be provided.")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	if isSet(config.ProductType) && program_serial_number == "" {
+		diags.AddError("Missing argument", "program_serial_number is required to filter by product_type.")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Find the configurations of this kind, the entitlements don't have a product type.
+	var config_ids map[int64]bool
+	if program_serial_number != "" {
+		config_ids = make(map[int64]bool)
+		request_obj := make(map[string]interface{})
+		request_obj["programSerialNumber"] = program_serial_number
+		if account_id != 0 {
+			request_obj["accountId"] = account_id
+		}
+		o, err := c.ReadConfigsList(&request_obj)
+		if err != nil {
+			diags.AddError("Unable to read configuration list", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		configs, _ := o["configs"].([]interface{})
+		for _, conf := range configs {
+			conf_map, ok := conf.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			product_type_id := 0
+			if pt, ok := conf_map["productType"].(map[string]interface{}); ok {
+				product_type_id = int(int64FromAPI(pt["id"]).ValueInt64())
+			}
+			if !l.isKind(product_type_id) {
+				continue
+			}
+			if isSet(config.ProductType) && fortiflexvm.ProductTypeId(config.ProductType.ValueString()) != product_type_id {
+				continue
+			}
+			config_ids[int64FromAPI(conf_map["id"]).ValueInt64()] = true
+		}
+	} else {
+		config_ids = make(map[int64]bool)
+		product_type_id, err := l.fortiClient.ConfigProductTypeId(int(config_id))
+		if err != nil {
+			diags.AddError("Unable to read configuration", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		if l.isKind(product_type_id) {
+			config_ids[config_id] = true
+		}
+	}
+
+	request_obj := make(map[string]interface{})
+	if config_id != 0 {
+		request_obj["configId"] = config_id
+	} else {
+		request_obj["accountId"] = account_id
+		request_obj["programSerialNumber"] = program_serial_number
+	}
+	if isSet(config.Description) {
+		request_obj["description"] = config.Description.ValueString()
+	}
+	if isSet(config.Status) {
+		request_obj["status"] = config.Status.ValueString()
+	}
+	o, err := c.ReadEntitlementsList(&request_obj)
+	if err != nil {
+		diags.AddError("Unable to read entitlement list", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	entitlements, _ := o["entitlements"].([]interface{})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, ent := range entitlements {
+			ent_map, ok := ent.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if config_ids != nil && !config_ids[int64FromAPI(ent_map["configId"]).ValueInt64()] {
+				continue
+			}
+
+			result := request.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("%v", ent_map["serialNumber"])
+			if description, ok := ent_map["description"].(string); ok && description != "" {
+				result.DisplayName = fmt.Sprintf("%v (%v)", ent_map["serialNumber"], description)
+			}
+			result.Diagnostics.Append(setEntitlementIdentity(ctx, result.Identity, types.StringValue(entitlementID(ent_map)))...)
+			if request.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, l.newModel(ent_map))...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+type listEntitlementsModel struct {
+	ConfigID            types.Int64  `tfsdk:"config_id"`
+	AccountID           types.Int64  `tfsdk:"account_id"`
+	ProgramSerialNumber types.String `tfsdk:"program_serial_number"`
+	ProductType         types.String `tfsdk:"product_type"`
+	Description         types.String `tfsdk:"description"`
+	Status              types.String `tfsdk:"status"`
+}
@@ -3,16 +3,24 @@ package framework
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ provider.Provider = &fwprovider{}
 var _ provider.ProviderWithEphemeralResources = &fwprovider{}
+var _ provider.ProviderWithActions = &fwprovider{}
+var _ provider.ProviderWithListResources = &fwprovider{}
+var _ provider.ProviderWithFunctions = &fwprovider{}
 
 // New returns a new, initialized Terraform Plugin Framework-style provider instance.
 // The provider instance is fully configured once the `Configure` method has been called.
@@ -39,12 +47,46 @@ func (f *fwprovider) Schema(ctx context.Context, request provider.SchemaRequest,
 			},
 			"password": schema.StringAttribute{
 				Optional:    true,
-				Description: "The API password.",
+				Sensitive:   true,
+				Description: "The API password. It accepts ephemeral values and is never saved in the state.",
 			},
 			"import_options": schema.SetAttribute{
-				ElementType: types.StringType,
+				ElementType:        types.StringType,
+				Optional:           true,
+				Description:        "Used in terraform import. Check fortiflexvm_config document for usage.",
+				DeprecationMessage: "Import fortiflexvm_config by identity or by the ID 'program_serial_number.config_id' instead.",
+			},
+			"budget_enforcement": schema.StringAttribute{
 				Optional:    true,
-				Description: "Used in terraform import. Check fortiflexvm_config document for usage.",
+				Description: "What to do when new entitlements exceed points_budget: \"block\" (default) fails the request, \"warn\" only warns.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("block", "warn"),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"points_budget": schema.ListNestedBlock{
+				Description: "Limit the points used by a program or a configuration.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"program_serial_number": schema.StringAttribute{
+							Optional:    true,
+							Description: "The serial number of the program. Set either program_serial_number or config_id.",
+						},
+						"config_id": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The ID of the configuration. Set either program_serial_number or config_id.",
+						},
+						"daily": schema.Float64Attribute{
+							Optional:    true,
+							Description: "The maximum points used in one day.",
+						},
+						"monthly": schema.Float64Attribute{
+							Optional:    true,
+							Description: "The maximum points used in 30 days.",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -56,6 +98,8 @@ func (f *fwprovider) Configure(ctx context.Context, request provider.ConfigureRe
 	response.DataSourceData = v
 	response.ResourceData = v
 	response.EphemeralResourceData = v
+	response.ActionData = v
+	response.ListResourceData = v
 }
 
 func (f *fwprovider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -63,11 +107,47 @@ func (f *fwprovider) DataSources(ctx context.Context) []func() datasource.DataSo
 }
 
 func (f *fwprovider) Resources(ctx context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{
+		NewResourceEntitlementsVM,
+		NewResourceEntitlementsHW,
+		NewResourceEntitlementsCloud,
+		NewResourceEntitlementsVMToken,
+	}
+}
+
+func (f *fwprovider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewListConfig,
+		NewListEntitlementsVM,
+		NewListEntitlementsHW,
+		NewListEntitlementsCloud,
+	}
 }
 
 func (f *fwprovider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
 		NewEphemeralGroupsNexttoken,
+		NewEphemeralEntitlementToken,
+		NewEphemeralAccessToken,
+	}
+}
+
+func (f *fwprovider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewActionEntitlementStop,
+		NewActionEntitlementReactivate,
+		NewActionEntitlementRegenerateToken,
+		NewActionConfigDisable,
+		NewActionConfigEnable,
+	}
+}
+
+func (f *fwprovider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewFunctionParseEntitlementID,
+		NewFunctionProductTypeID,
+		NewFunctionParameterName,
+		NewFunctionNormalizeEndDate,
+		NewFunctionConfigCostEstimate,
 	}
 }
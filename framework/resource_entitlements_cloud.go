@@ -0,0 +1,414 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ resource.Resource = &resourceEntitlementsCloud{}
+var _ resource.ResourceWithConfigure = &resourceEntitlementsCloud{}
+var _ resource.ResourceWithImportState = &resourceEntitlementsCloud{}
+var _ resource.ResourceWithIdentity = &resourceEntitlementsCloud{}
+var _ resource.ResourceWithModifyPlan = &resourceEntitlementsCloud{}
+
+type resourceEntitlementsCloud struct {
+	fortiClient *fortiflexvm.FortiClient
+}
+
+func NewResourceEntitlementsCloud() resource.Resource {
+	return &resourceEntitlementsCloud{}
+}
+
+func (r *resourceEntitlementsCloud) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "fortiflexvm_entitlements_cloud"
+	// The identity contains config_id, which can be changed in place
+	response.ResourceBehavior = resource.ResourceBehavior{
+		MutableIdentity: true,
+	}
+}
+
+func (r *resourceEntitlementsCloud) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.fortiClient = client
+}
+
+func (r *resourceEntitlementsCloud) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Create and update one Cloud entitlement based on a configuration.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"config_id": schema.Int64Attribute{
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"end_date": schema.StringAttribute{
+				CustomType: endDateType{},
+				Optional:   true,
+				Computed:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("end_date_policy")),
+				},
+			},
+			"folder_path": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial_number": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(ENTITLEMENT_STATUSES...),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"end_date_policy": endDatePolicyBlock(),
+		},
+	}
+}
+
+func (r *resourceEntitlementsCloud) IdentitySchema(ctx context.Context, request resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = entitlementIdentitySchema()
+}
+
+func (r *resourceEntitlementsCloud) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	modifyEntitlementIDPlan(ctx, request, response)
+	// The status of a new entitlement is only known once it is created
+	modifyEntitlementStatusPlan(ctx, request, response, "")
+	modifyEndDatePolicyPlan(ctx, r.fortiClient, request, response)
+	modifyEndDatePlan(ctx, r.fortiClient, request, response)
+}
+
+func (r *resourceEntitlementsCloud) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan resourceEntitlementsCloudModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	c := r.fortiClient.Client
+
+	// If the user does not specify serial_number, create a new one, else, retrieve the old one.
+	if isSet(plan.SerialNumber) {
+		// Query existing entitlement, then only send update request
+		target_entitlement, err := readEntitlement(c, plan.SerialNumber.ValueString(), plan.ConfigID.ValueInt64())
+		if err != nil {
+			response.Diagnostics.AddError("Unable to read entitlement", err.Error())
+			return
+		}
+		response.Diagnostics.Append(r.update(plan.SerialNumber.ValueString(), target_entitlement, &plan)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+		response.Diagnostics.Append(setEntitlementIdentity(ctx, response.Identity, plan.ID)...)
+		return
+	}
+
+	// Send create request
+	obj := make(map[string]interface{})
+	obj["configId"] = plan.ConfigID.ValueInt64()
+	obj["count"] = 1
+	if isSet(plan.Description) {
+		obj["description"] = plan.Description.ValueString()
+	}
+	if isSet(plan.FolderPath) {
+		obj["folderPath"] = plan.FolderPath.ValueString()
+	}
+	if isSet(plan.EndDate.StringValue) {
+		end_date, err := normalizeEndDate(plan.EndDate.ValueString())
+		if err != nil {
+			response.Diagnostics.AddError("Unable to parse end_date", err.Error())
+			return
+		}
+		obj["endDate"] = end_date
+	}
+	response.Diagnostics.Append(checkPointsBudget(r.fortiClient, plan.ConfigID.ValueInt64(), 1)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	target_entitlement, err := c.CreateEntitlementsCloud(&obj)
+	if err != nil {
+		r.fortiClient.ReleasePointsBudget(int(plan.ConfigID.ValueInt64()), 1)
+		response.Diagnostics.AddError("Unable to create entitlement", err.Error())
+		return
+	}
+
+	// Save the ID first, so the entitlement is tracked even if the status change fails
+	plan.ID = types.StringValue(entitlementID(target_entitlement))
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+	response.Diagnostics.Append(setEntitlementIdentity(ctx, response.Identity, plan.ID)...)
+
+	current_status, _ := target_entitlement["status"].(string)
+	serial_number := fmt.Sprintf("%v", target_entitlement["serialNumber"])
+	changed_entitlement, diags := changeEntitlementStatusByPlan(r.fortiClient, serial_number, plan.ConfigID.ValueInt64(), current_status, plan.Status)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if changed_entitlement != nil {
+		target_entitlement = changed_entitlement
+	}
+
+	plan.refresh(target_entitlement)
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(setEntitlementIdentity(ctx, response.Identity, plan.ID)...)
+}
+
+func (r *resourceEntitlementsCloud) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state resourceEntitlementsCloudModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	serial_number, config_id, err := splitEntitlementID(state.ID.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Unable to handle id in fortiflexvm_entitlements_cloud", err.Error())
+		return
+	}
+	target_entitlement, err := readEntitlement(r.fortiClient.Client, serial_number, config_id)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to read entitlement", err.Error())
+		return
+	}
+
+	state.refresh(target_entitlement)
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+	response.Diagnostics.Append(setEntitlementIdentity(ctx, response.Identity, state.ID)...)
+}
+
+func (r *resourceEntitlementsCloud) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan, state resourceEntitlementsCloudModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Check status first
+	serial_number, previous_config_id, err := splitEntitlementID(state.ID.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Unable to handle id in fortiflexvm_entitlements_cloud", err.Error())
+		return
+	}
+	target_entitlement, err := readEntitlement(r.fortiClient.Client, serial_number, previous_config_id)
+	if err != nil {
+		response.Diagnostics.AddError("Unable to read entitlement", err.Error())
+		return
+	}
+
+	response.Diagnostics.Append(r.update(serial_number, target_entitlement, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(setEntitlementIdentity(ctx, response.Identity, plan.ID)...)
+}
+
+// update changes the status of the entitlement and sends the update request.
+// The model is refreshed from the response.
+func (r *resourceEntitlementsCloud) update(serial_number string, target_entitlement map[string]interface{}, plan *resourceEntitlementsCloudModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+	c := r.fortiClient.Client
+
+	// Check status
+	current_status, _ := target_entitlement["status"].(string)
+	_, status_diags := changeEntitlementStatusByPlan(r.fortiClient, serial_number, plan.ConfigID.ValueInt64(), current_status, plan.Status)
+	diags.Append(status_diags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Send update request
+	obj := make(map[string]interface{})
+	obj["serialNumber"] = serial_number
+	obj["configId"] = plan.ConfigID.ValueInt64()
+	if isSet(plan.Description) {
+		obj["description"] = plan.Description.ValueString()
+	}
+	if isSet(plan.FolderPath) && !sameFolderPath(plan.FolderPath.ValueString(), fmt.Sprintf("%v", target_entitlement["folderPath"])) {
+		// Move the entitlement to another folder
+		obj["folderPath"] = plan.FolderPath.ValueString()
+	}
+	end_date, end_date_diags := endDateUpdate(plan.EndDate, target_entitlement["endDate"])
+	diags.Append(end_date_diags...)
+	if end_date != "" {
+		obj["endDate"] = end_date
+	}
+	target_entitlement, err = c.UpdateVmUpdate(&obj)
+	if err != nil {
+		diags.AddError("Unable to update entitlement", err.Error())
+		return diags
+	}
+
+	plan.refresh(target_entitlement)
+	return diags
+}
+
+func (r *resourceEntitlementsCloud) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var state resourceEntitlementsCloudModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	c := r.fortiClient.Client
+
+	serial_number, config_id, err := splitEntitlementID(state.ID.ValueString())
+	if err != nil {
+		response.Diagnostics.AddError("Unable to handle id in fortiflexvm_entitlements_cloud", err.Error())
+		return
+	}
+
+	// If entitlement is ACTIVE, stop it.
+	target_entitlement, err := readEntitlement(c, serial_number, config_id)
+	if err != nil || target_entitlement["status"] != "STOPPED" {
+		_, err = changeEntitlementStatus(c, serial_number, "stop")
+		if err != nil {
+			response.Diagnostics.AddError("Unable to stop entitlement", err.Error())
+			return
+		}
+	}
+}
+
+func (r *resourceEntitlementsCloud) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	importEntitlementState(ctx, request, response)
+}
+
+type resourceEntitlementsCloudModel struct {
+	ID            types.String        `tfsdk:"id"`
+	AccountID     types.Int64         `tfsdk:"account_id"`
+	ConfigID      types.Int64         `tfsdk:"config_id"`
+	Description   types.String        `tfsdk:"description"`
+	EndDate       endDateValue        `tfsdk:"end_date"`
+	FolderPath    types.String        `tfsdk:"folder_path"`
+	SerialNumber  types.String        `tfsdk:"serial_number"`
+	StartDate     types.String        `tfsdk:"start_date"`
+	Status        types.String        `tfsdk:"status"`
+	EndDatePolicy *endDatePolicyModel `tfsdk:"end_date_policy"`
+}
+
+func (m *resourceEntitlementsCloudModel) refresh(o map[string]interface{}) {
+	m.ID = types.StringValue(entitlementID(o))
+	if value, ok := o["accountId"]; ok {
+		m.AccountID = int64FromAPI(value)
+	}
+	if value, ok := o["configId"]; ok {
+		m.ConfigID = int64FromAPI(value)
+	}
+	if value, ok := o["description"]; ok {
+		m.Description = stringFromAPI(value)
+	}
+	if value, ok := o["endDate"]; ok {
+		m.EndDate = endDateFromAPI(value)
+	}
+	if value, ok := o["folderPath"]; ok && value != nil {
+		// Keep the configured value if it only differs by the trailing slash
+		if folder_path := fmt.Sprintf("%v", value); !sameFolderPath(m.FolderPath.ValueString(), folder_path) {
+			m.FolderPath = types.StringValue(folder_path)
+		}
+	}
+	if value, ok := o["serialNumber"]; ok {
+		m.SerialNumber = stringFromAPI(value)
+	}
+	if value, ok := o["status"]; ok {
+		m.Status = stringFromAPI(value)
+	}
+	if value, ok := o["startDate"]; ok {
+		m.StartDate = stringFromAPI(value)
+	}
+	m.setUnknownToNull()
+}
+
+func (m *resourceEntitlementsCloudModel) setUnknownToNull() {
+	if m.AccountID.IsUnknown() {
+		m.AccountID = types.Int64Null()
+	}
+	if m.Description.IsUnknown() {
+		m.Description = types.StringNull()
+	}
+	if m.EndDate.IsUnknown() {
+		m.EndDate = endDateNull()
+	}
+	if m.FolderPath.IsUnknown() {
+		m.FolderPath = types.StringNull()
+	}
+	if m.SerialNumber.IsUnknown() {
+		m.SerialNumber = types.StringNull()
+	}
+	if m.StartDate.IsUnknown() {
+		m.StartDate = types.StringNull()
+	}
+	if m.Status.IsUnknown() {
+		m.Status = types.StringNull()
+	}
+}
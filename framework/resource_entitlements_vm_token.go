@@ -0,0 +1,235 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ resource.Resource = &resourceEntitlementsVMToken{}
+var _ resource.ResourceWithConfigure = &resourceEntitlementsVMToken{}
+var _ resource.ResourceWithImportState = &resourceEntitlementsVMToken{}
+var _ resource.ResourceWithModifyPlan = &resourceEntitlementsVMToken{}
+
+type resourceEntitlementsVMToken struct {
+	fortiClient *fortiflexvm.FortiClient
+}
+
+func NewResourceEntitlementsVMToken() resource.Resource {
+	return &resourceEntitlementsVMToken{}
+}
+
+func (r *resourceEntitlementsVMToken) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = "fortiflexvm_entitlements_vm_token"
+}
+
+func (r *resourceEntitlementsVMToken) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*fortiflexvm.FortiClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *FortiClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.fortiClient = client
+}
+
+func (r *resourceEntitlementsVMToken) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: "Regenerate the token of one VM entitlement.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"serial_number": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"config_id": schema.Int64Attribute{
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"regenerate_token": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token_status": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token_storage":   tokenStorageAttribute(),
+			"pgp_key":         pgpKeyAttribute(),
+			"encrypted_token": encryptedTokenAttribute(),
+		},
+	}
+}
+
+func (r *resourceEntitlementsVMToken) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	modifyEntitlementIDPlan(ctx, request, response)
+	modifyTokenStoragePlan(ctx, request, response)
+}
+
+func (r *resourceEntitlementsVMToken) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var plan resourceEntitlementsVMTokenModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(r.update(&plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(plan.storeToken(ctx, response.Private)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *resourceEntitlementsVMToken) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var state resourceEntitlementsVMTokenModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(r.read(&state)...)
+	response.Diagnostics.Append(state.storeToken(ctx, response.Private)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, state)...)
+}
+
+func (r *resourceEntitlementsVMToken) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan resourceEntitlementsVMTokenModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(r.update(&plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(plan.storeToken(ctx, response.Private)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *resourceEntitlementsVMToken) update(plan *resourceEntitlementsVMTokenModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	regenerate_token := plan.RegenerateToken.ValueBool()
+	if regenerate_token {
+		_, err := regenerateEntitlementToken(r.fortiClient.Client, plan.SerialNumber.ValueString())
+		if err != nil {
+			diags.AddError("Unable to regenerate token", err.Error())
+			return diags
+		}
+	}
+	plan.ID = types.StringValue(fmt.Sprintf("%v.%v", plan.SerialNumber.ValueString(), plan.ConfigID.ValueInt64()))
+
+	diags.Append(r.read(plan)...)
+	// Keep the planned value, Terraform requires the result to match the plan.
+	plan.RegenerateToken = types.BoolValue(regenerate_token)
+	return diags
+}
+
+func (r *resourceEntitlementsVMToken) read(m *resourceEntitlementsVMTokenModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	serial_number, config_id, err := splitEntitlementID(m.ID.ValueString())
+	if err != nil {
+		diags.AddError("Unable to handle id in fortiflexvm_entitlements_vm_token", err.Error())
+		return diags
+	}
+	target_entitlement, err := readEntitlement(r.fortiClient.Client, serial_number, config_id)
+	if err != nil {
+		diags.AddError("Unable to read entitlement", err.Error())
+		return diags
+	}
+	m.AccountID = int64FromAPI(target_entitlement["accountId"])
+	m.ConfigID = int64FromAPI(target_entitlement["configId"])
+	m.SerialNumber = stringFromAPI(target_entitlement["serialNumber"])
+	m.RegenerateToken = types.BoolValue(false)
+	m.Token = stringFromAPI(target_entitlement["token"])
+	m.TokenStatus = stringFromAPI(target_entitlement["tokenStatus"])
+	return diags
+}
+
+func (r *resourceEntitlementsVMToken) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	// Nothing to delete on FortiFlex
+}
+
+func (r *resourceEntitlementsVMToken) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+}
+
+type resourceEntitlementsVMTokenModel struct {
+	ID              types.String `tfsdk:"id"`
+	AccountID       types.Int64  `tfsdk:"account_id"`
+	SerialNumber    types.String `tfsdk:"serial_number"`
+	ConfigID        types.Int64  `tfsdk:"config_id"`
+	RegenerateToken types.Bool   `tfsdk:"regenerate_token"`
+	Token           types.String `tfsdk:"token"`
+	TokenStatus     types.String `tfsdk:"token_status"`
+	TokenStorage    types.String `tfsdk:"token_storage"`
+	PgpKey          types.String `tfsdk:"pgp_key"`
+	EncryptedToken  types.String `tfsdk:"encrypted_token"`
+}
+
+// storeToken applies token_storage to the token read from FortiFlex.
+func (m *resourceEntitlementsVMTokenModel) storeToken(ctx context.Context, private privateState) diag.Diagnostics {
+	return storeToken(ctx, private, m.TokenStorage, m.PgpKey, &m.Token, &m.EncryptedToken)
+}
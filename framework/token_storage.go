@@ -0,0 +1,122 @@
+package framework
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+// TOKEN_SHA256_KEY is the private state key of the hash of the encrypted token and pgp_key.
+const TOKEN_SHA256_KEY = "token_sha256"
+
+// privateState is the private state of a resource in requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func tokenStorageAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "How the token is saved in the state: \"plain\" (default), \"omit\" to not save it, or \"pgp\" to save it encrypted with pgp_key in encrypted_token.",
+		Validators: []validator.String{
+			stringvalidator.OneOf(fortiflexvm.TOKEN_STORAGE_MODES...),
+		},
+	}
+}
+
+func pgpKeyAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "The PGP public key, ASCII armored or base64 encoded, used when token_storage is \"pgp\".",
+	}
+}
+
+func encryptedTokenAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:    true,
+		Description: "The token encrypted with pgp_key and base64 encoded, when token_storage is \"pgp\".",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// storeToken applies token_storage to the token read from FortiFlex. With "pgp", the
+// previous encrypted_token is kept while the token and pgp_key don't change, their hash
+// is saved in the private state.
+func storeToken(ctx context.Context, private privateState, token_storage types.String, pgp_key types.String, token *types.String, encrypted_token *types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	switch token_storage.ValueString() {
+	case "omit":
+		*token = types.StringNull()
+		*encrypted_token = types.StringNull()
+	case "pgp":
+		plain := token.ValueString()
+		*token = types.StringNull()
+		if plain == "" {
+			*encrypted_token = types.StringNull()
+			return diags
+		}
+		hash, _ := json.Marshal(fortiflexvm.TokenSha256(plain, pgp_key.ValueString()))
+		previous, get_diags := private.GetKey(ctx, TOKEN_SHA256_KEY)
+		diags.Append(get_diags...)
+		if isSet(*encrypted_token) && bytes.Equal(previous, hash) {
+			return diags
+		}
+		value, err := fortiflexvm.EncryptToken(plain, pgp_key.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("pgp_key"), "Unable to encrypt token", err.Error())
+			return diags
+		}
+		*encrypted_token = types.StringValue(value)
+		diags.Append(private.SetKey(ctx, TOKEN_SHA256_KEY, hash)...)
+	default:
+		*encrypted_token = types.StringNull()
+	}
+	return diags
+}
+
+// modifyTokenStoragePlan checks pgp_key, and marks token and encrypted_token unknown
+// when token_storage or pgp_key change, as they are saved again.
+func modifyTokenStoragePlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		return
+	}
+	var token_storage, pgp_key types.String
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("token_storage"), &token_storage)...)
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("pgp_key"), &pgp_key)...)
+	if response.Diagnostics.HasError() || token_storage.IsUnknown() || pgp_key.IsUnknown() {
+		return
+	}
+	if err := fortiflexvm.CheckTokenStorage(token_storage.ValueString(), pgp_key.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(path.Root("pgp_key"), "Invalid token_storage", err.Error())
+		return
+	}
+	if request.State.Raw.IsNull() {
+		return
+	}
+
+	var current_token_storage, current_pgp_key types.String
+	response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("token_storage"), &current_token_storage)...)
+	response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("pgp_key"), &current_pgp_key)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if current_token_storage.ValueString() == token_storage.ValueString() && current_pgp_key.ValueString() == pgp_key.ValueString() {
+		return
+	}
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())...)
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("encrypted_token"), types.StringUnknown())...)
+}
@@ -0,0 +1,105 @@
+package framework
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testPrivateState is a privateState kept in memory.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func testPGPKey(t *testing.T) string {
+	t.Helper()
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buffer bytes.Buffer
+	writer, err := armor.Encode(&buffer, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = entity.Serialize(writer); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+	return buffer.String()
+}
+
+func TestStoreToken(t *testing.T) {
+	ctx := context.Background()
+	pgp_key := testPGPKey(t)
+	other_pgp_key := testPGPKey(t)
+
+	// The state after a first apply with token_storage = "pgp"
+	private := testPrivateState{}
+	token := types.StringValue("TOKEN")
+	var previous_encrypted types.String
+	if diags := storeToken(ctx, private, types.StringValue("pgp"), types.StringValue(pgp_key), &token, &previous_encrypted); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !token.IsNull() || !isSet(previous_encrypted) {
+		t.Fatalf("storeToken() = (%v, %v), want a null token and an encrypted token", token, previous_encrypted)
+	}
+
+	cases := []struct {
+		name          string
+		token_storage types.String
+		pgp_key       string
+		token         types.String
+		want_token    types.String
+		encrypted     bool
+		kept          bool
+		err           bool
+	}{
+		{"default", types.StringNull(), "", types.StringValue("TOKEN"), types.StringValue("TOKEN"), false, false, false},
+		{"plain", types.StringValue("plain"), "", types.StringValue("TOKEN"), types.StringValue("TOKEN"), false, false, false},
+		{"omit", types.StringValue("omit"), "", types.StringValue("TOKEN"), types.StringNull(), false, false, false},
+		{"pgp same token and key", types.StringValue("pgp"), pgp_key, types.StringValue("TOKEN"), types.StringNull(), true, true, false},
+		{"pgp new token", types.StringValue("pgp"), pgp_key, types.StringValue("NEW_TOKEN"), types.StringNull(), true, false, false},
+		{"pgp new key", types.StringValue("pgp"), other_pgp_key, types.StringValue("TOKEN"), types.StringNull(), true, false, false},
+		{"pgp without token", types.StringValue("pgp"), pgp_key, types.StringNull(), types.StringNull(), false, false, false},
+		{"pgp invalid key", types.StringValue("pgp"), "not a key", types.StringValue("NEW_TOKEN"), types.StringNull(), false, false, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			case_private := testPrivateState{}
+			for key, value := range private {
+				case_private[key] = value
+			}
+			token := c.token
+			encrypted_token := previous_encrypted
+			diags := storeToken(ctx, case_private, c.token_storage, types.StringValue(c.pgp_key), &token, &encrypted_token)
+			if diags.HasError() != c.err {
+				t.Fatalf("storeToken() diagnostics = %v, want error %v", diags, c.err)
+			}
+			if c.err {
+				return
+			}
+			if !token.Equal(c.want_token) {
+				t.Errorf("token = %v, want %v", token, c.want_token)
+			}
+			if isSet(encrypted_token) != c.encrypted {
+				t.Fatalf("encrypted_token = %v, want encrypted %v", encrypted_token, c.encrypted)
+			}
+			if c.encrypted && encrypted_token.Equal(previous_encrypted) != c.kept {
+				t.Errorf("encrypted_token kept = %v, want %v", encrypted_token.Equal(previous_encrypted), c.kept)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+)
+
+var _ basetypes.StringTypable = endDateType{}
+var _ basetypes.StringValuableWithSemanticEquals = endDateValue{}
+var _ xattr.ValidateableAttribute = endDateValue{}
+
+// END_DATE_FORMAT is the format of the end dates returned by FortiFlex, in UTC.
+const END_DATE_FORMAT = "2006-01-02T15:04:05"
+
+// endDateType is the type of the end_date of entitlements. Any ISO 8601 date is accepted,
+// and a value describing the same time as the one returned by FortiFlex, e.g. "2025-12-31"
+// and "2025-12-31T00:00:00", doesn't cause a diff.
+type endDateType struct {
+	basetypes.StringType
+}
+
+func (t endDateType) Equal(o attr.Type) bool {
+	other, ok := o.(endDateType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t endDateType) String() string {
+	return "endDateType"
+}
+
+func (t endDateType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return endDateValue{StringValue: in}, nil
+}
+
+func (t endDateType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attr_value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	string_value, ok := attr_value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attr_value)
+	}
+	return endDateValue{StringValue: string_value}, nil
+}
+
+func (t endDateType) ValueType(ctx context.Context) attr.Value {
+	return endDateValue{}
+}
+
+type endDateValue struct {
+	basetypes.StringValue
+}
+
+func endDateNull() endDateValue {
+	return endDateValue{StringValue: types.StringNull()}
+}
+
+func endDateFromAPI(v interface{}) endDateValue {
+	return endDateValue{StringValue: stringFromAPI(v)}
+}
+
+func (v endDateValue) Equal(o attr.Value) bool {
+	other, ok := o.(endDateValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v endDateValue) Type(ctx context.Context) attr.Type {
+	return endDateType{}
+}
+
+func (v endDateValue) StringSemanticEquals(ctx context.Context, new_valuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	new_value, ok := new_valuable.(endDateValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, new_valuable))
+		return false, diags
+	}
+	return sameEndDate(v.ValueString(), new_value.ValueString()), diags
+}
+
+func (v endDateValue) ValidateAttribute(ctx context.Context, request xattr.ValidateAttributeRequest, response *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if _, err := tryParseISO8601(v.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid end_date",
+			fmt.Sprintf("Unable to parse %v, please use ISO 8601 format, e.g. 2025-12-31 or 2025-12-31T00:00:00: %v", v.ValueString(), err),
+		)
+	}
+}
+
+// normalizeEndDate converts an ISO 8601 date to the format of FortiFlex.
+func normalizeEndDate(end_date string) (string, error) {
+	t, err := tryParseISO8601(end_date)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(END_DATE_FORMAT), nil
+}
+
+// endDateUpdate returns the end date to send in the update request, "" if the planned
+// end date is not set or equals the current one. Past end dates are ignored with a warning.
+func endDateUpdate(planned endDateValue, current interface{}) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !isSet(planned.StringValue) || (current != nil && sameEndDate(planned.ValueString(), fmt.Sprintf("%v", current))) {
+		return "", diags
+	}
+	end_date, err := tryParseISO8601(planned.ValueString())
+	if err != nil {
+		diags.AddWarning(
+			"Unable to parse end_date, ignoring update end_date",
+			fmt.Sprintf("Unable to parse %v, please check the format.", planned.ValueString()),
+		)
+		return "", diags
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if !today.Before(end_date) {
+		diags.AddWarning(
+			"end_date can not be before today's date, ignoring update end_date",
+			fmt.Sprintf("today's date: %v, end_date: %v.", today.Format(END_DATE_FORMAT), end_date.UTC().Format(END_DATE_FORMAT)),
+		)
+		return "", diags
+	}
+	return end_date.UTC().Format(END_DATE_FORMAT), diags
+}
+
+// modifyEndDatePlan checks a new or changed end_date: it must be after today, and
+// not after the end date of the program of the configuration.
+func modifyEndDatePlan(ctx context.Context, client *fortiflexvm.FortiClient, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+	var planned, current endDateValue
+	var config_id types.Int64
+	response.Diagnostics.Append(response.Plan.GetAttribute(ctx, path.Root("end_date"), &planned)...)
+	response.Diagnostics.Append(response.Plan.GetAttribute(ctx, path.Root("config_id"), &config_id)...)
+	if response.Diagnostics.HasError() || !isSet(planned.StringValue) || config_id.IsNull() || config_id.IsUnknown() {
+		return
+	}
+	if !request.State.Raw.IsNull() {
+		response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("end_date"), &current)...)
+		if response.Diagnostics.HasError() || sameEndDate(planned.ValueString(), current.ValueString()) {
+			return
+		}
+	}
+	end_date, err := tryParseISO8601(planned.ValueString())
+	if err != nil {
+		// Reported by ValidateAttribute
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if !today.Before(end_date) {
+		response.Diagnostics.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid end_date",
+			fmt.Sprintf("end_date %v must be after today's date %v (UTC).", planned.ValueString(), today.Format(END_DATE_FORMAT)),
+		)
+		return
+	}
+
+	program_serial_number, program_end_date, err := client.ProgramEndDate(int(config_id.ValueInt64()))
+	if err != nil {
+		response.Diagnostics.AddAttributeWarning(
+			path.Root("end_date"),
+			"Unable to check end_date",
+			fmt.Sprintf("Unable to find the end date of the program of configuration %v, end_date is not checked: %v", config_id.ValueInt64(), err),
+		)
+		return
+	}
+	program_end, err := tryParseISO8601(program_end_date)
+	if err != nil {
+		return
+	}
+	if end_date.After(program_end) {
+		response.Diagnostics.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid end_date",
+			fmt.Sprintf("end_date %v is after the end date %v of program %v. Use an earlier end_date or extend the program.",
+				planned.ValueString(), program_end_date, program_serial_number),
+		)
+	}
+}
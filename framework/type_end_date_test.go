@@ -0,0 +1,78 @@
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeEndDate(t *testing.T) {
+	cases := []struct {
+		end_date string
+		want     string
+		err      bool
+	}{
+		{"2025-12-31", "2025-12-31T00:00:00", false},
+		{"2025-12-31T10", "2025-12-31T10:00:00", false},
+		{"2025-12-31T10:30", "2025-12-31T10:30:00", false},
+		{"2025-12-31T10:30:15", "2025-12-31T10:30:15", false},
+		{"2025-12-31T10:30:15.5", "2025-12-31T10:30:15", false},
+		{"2025-12-31T10:30:15Z", "2025-12-31T10:30:15", false},
+		{"2025-12-31T10:30:15+02:00", "2025-12-31T08:30:15", false},
+		{"2026-01-01T01:00:00+02:00", "2025-12-31T23:00:00", false},
+		{"31/12/2025", "", true},
+		{"", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.end_date, func(t *testing.T) {
+			got, err := normalizeEndDate(c.end_date)
+			if (err != nil) != c.err {
+				t.Fatalf("normalizeEndDate(%q) error = %v, want error %v", c.end_date, err, c.err)
+			}
+			if got != c.want {
+				t.Errorf("normalizeEndDate(%q) = %q, want %q", c.end_date, got, c.want)
+			}
+		})
+	}
+}
+
+func TestEndDateSemanticEquals(t *testing.T) {
+	cases := []struct {
+		name    string
+		current string
+		planned string
+		want    bool
+	}{
+		{"same value", "2025-12-31T00:00:00", "2025-12-31T00:00:00", true},
+		{"date only", "2025-12-31T00:00:00", "2025-12-31", true},
+		{"without seconds", "2025-12-31T10:30:00", "2025-12-31T10:30", true},
+		{"UTC offset", "2025-12-31T08:30:00", "2025-12-31T10:30:00+02:00", true},
+		{"Z suffix", "2025-12-31T08:30:00", "2025-12-31T08:30:00Z", true},
+		{"other day", "2025-12-31T00:00:00", "2026-01-01", false},
+		{"other hour", "2025-12-31T00:00:00", "2025-12-31T01", false},
+		{"invalid planned", "2025-12-31T00:00:00", "31/12/2025", false},
+		{"invalid current", "unknown", "2025-12-31", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			current := endDateValue{StringValue: types.StringValue(c.current)}
+			planned := endDateValue{StringValue: types.StringValue(c.planned)}
+			got, diags := current.StringSemanticEquals(context.Background(), planned)
+			if diags.HasError() {
+				t.Fatalf("StringSemanticEquals(%q, %q) returned errors: %v", c.current, c.planned, diags)
+			}
+			if got != c.want {
+				t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", c.current, c.planned, got, c.want)
+			}
+		})
+	}
+}
+
+func TestEndDateSemanticEqualsOtherType(t *testing.T) {
+	current := endDateValue{StringValue: types.StringValue("2025-12-31T00:00:00")}
+	_, diags := current.StringSemanticEquals(context.Background(), types.StringValue("2025-12-31"))
+	if !diags.HasError() {
+		t.Errorf("StringSemanticEquals with a basetypes.StringValue returned no error")
+	}
+}
@@ -0,0 +1,286 @@
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/terraform-providers/terraform-provider-fortiflexvm/fortiflexvm"
+	forticlient "github.com/terraform-providers/terraform-provider-fortiflexvm/sdk/sdkcore"
+)
+
+// splitEntitlementID splits the resource ID 'serial_number.config_id' of an entitlement.
+func splitEntitlementID(resource_id string) (string, int64, error) {
+	split_parts := strings.Split(resource_id, ".")
+	if len(split_parts) != 2 {
+		return "", 0, fmt.Errorf("Incorrect id format: %v. Please use 'serial_number' + '.' + 'config_id', example: 'FGVMMLTM12345678.123'", resource_id)
+	}
+	serial_number := split_parts[0]
+	config_id, err := strconv.ParseInt(split_parts[1], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("The id you import in fortiflexvm_entitlement is incorrect. "+
+			"Please use 'serial_number' + '.' + 'config_id', example: 'FGVMMLTM12345678.123'. "+
+			"Your serial_number: %s, your config_id: %s (should be an integer).", serial_number, split_parts[1])
+	}
+	return serial_number, config_id, nil
+}
+
+// readEntitlement queries one entitlement by its serial number and config ID.
+func readEntitlement(c *forticlient.FortiSDKClient, serial_number string, config_id int64) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	obj["configId"] = config_id
+	obj["serialNumber"] = serial_number
+	return_data, err := c.ReadEntitlementsList(&obj)
+	if err != nil {
+		return nil, err
+	}
+	if return_data == nil {
+		return nil, fmt.Errorf("response from FlexVM API is nil")
+	}
+	if ent_list, ok := return_data["entitlements"].([]interface{}); ok {
+		for _, data_ent := range ent_list {
+			if ent, ok := data_ent.(map[string]interface{}); ok {
+				if fmt.Sprintf("%v", ent["serialNumber"]) == serial_number {
+					return ent, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("target entitlement %v not exist", serial_number)
+}
+
+// changeEntitlementStatus sends 'stop' or 'reactivate' request for one entitlement.
+func changeEntitlementStatus(c *forticlient.FortiSDKClient, serial_number string, action string) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	obj["serialNumber"] = serial_number
+	return c.UpdateVmUpdateStatus(&obj, action)
+}
+
+// regenerateEntitlementToken regenerates the token of one VM entitlement.
+func regenerateEntitlementToken(c *forticlient.FortiSDKClient, serial_number string) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	obj["serialNumber"] = serial_number
+	return c.UpdateVmUpdateRegenerateToken(&obj)
+}
+
+// checkPointsBudget checks the points budget of the provider before
+// 'entitlement_num' entitlements of the configuration are created or reactivated.
+func checkPointsBudget(client *fortiflexvm.FortiClient, config_id int64, entitlement_num int) diag.Diagnostics {
+	warnings, err := client.CheckPointsBudget(int(config_id), entitlement_num)
+	return pointsBudgetDiagnostics(warnings, err)
+}
+
+func pointsBudgetDiagnostics(warnings []string, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags.AddWarning("Points budget", warning)
+	}
+	if errors.Is(err, fortiflexvm.ErrPointsBudgetExceeded) {
+		diags.AddError("Points budget exceeded", err.Error())
+	} else if err != nil {
+		diags.AddError("Unable to check points budget", err.Error())
+	}
+	return diags
+}
+
+func entitlementID(o map[string]interface{}) string {
+	return fmt.Sprintf("%v.%v", o["serialNumber"], o["configId"])
+}
+
+// modifyEntitlementIDPlan marks the planned id unknown when config_id changes,
+// the ID 'serial_number.config_id' is only known after the update.
+func modifyEntitlementIDPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() || request.State.Raw.IsNull() {
+		return
+	}
+	var planned, current types.Int64
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("config_id"), &planned)...)
+	response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root("config_id"), &current)...)
+	if response.Diagnostics.HasError() || planned.Equal(current) {
+		return
+	}
+	response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+}
+
+// entitlementIdentitySchema is the identity of entitlement resources, it
+// matches the resource ID 'serial_number.config_id'. config_id can change in place, so the
+// resources using it set MutableIdentity.
+func entitlementIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"serial_number": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The serial number of the entitlement.",
+			},
+			"config_id": identityschema.Int64Attribute{
+				RequiredForImport: true,
+				Description:       "The ID of the configuration of the entitlement.",
+			},
+		},
+	}
+}
+
+type entitlementIdentityModel struct {
+	SerialNumber types.String `tfsdk:"serial_number"`
+	ConfigID     types.Int64  `tfsdk:"config_id"`
+}
+
+func newEntitlementIdentity(resource_id string) (entitlementIdentityModel, error) {
+	serial_number, config_id, err := splitEntitlementID(resource_id)
+	if err != nil {
+		return entitlementIdentityModel{}, err
+	}
+	return entitlementIdentityModel{
+		SerialNumber: types.StringValue(serial_number),
+		ConfigID:     types.Int64Value(config_id),
+	}, nil
+}
+
+// setEntitlementIdentity saves the identity of the entitlement 'resource_id'.
+func setEntitlementIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, resource_id types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if identity == nil {
+		return diags
+	}
+	m, err := newEntitlementIdentity(resource_id.ValueString())
+	if err != nil {
+		diags.AddError("Unable to set resource identity", err.Error())
+		return diags
+	}
+	return identity.Set(ctx, m)
+}
+
+// importEntitlementState imports one entitlement by the ID 'serial_number.config_id'
+// or by its identity.
+func importEntitlementState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID != "" {
+		if _, _, err := splitEntitlementID(request.ID); err != nil {
+			response.Diagnostics.AddError("Unable to import entitlement", err.Error())
+			return
+		}
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+		return
+	}
+
+	var identity entitlementIdentityModel
+	response.Diagnostics.Append(request.Identity.Get(ctx, &identity)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	resource_id := fmt.Sprintf("%v.%v", identity.SerialNumber.ValueString(), identity.ConfigID.ValueInt64())
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), resource_id)...)
+}
+
+// isSet reports whether a planned string value is known and not empty.
+func isSet(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown() && v.ValueString() != ""
+}
+
+func stringFromAPI(v interface{}) types.String {
+	if v == nil {
+		return types.StringValue("")
+	}
+	return types.StringValue(fmt.Sprintf("%v", v))
+}
+
+func int64FromAPI(v interface{}) types.Int64 {
+	switch i := v.(type) {
+	case float64:
+		return types.Int64Value(int64(i))
+	case int:
+		return types.Int64Value(int64(i))
+	case int64:
+		return types.Int64Value(i)
+	case string:
+		if n, err := strconv.ParseInt(i, 10, 64); err == nil {
+			return types.Int64Value(n)
+		}
+	}
+	return types.Int64Null()
+}
+
+func tryParseISO8601(timeStr string) (time.Time, error) {
+	var layouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02T15",
+		"2006-01-02",
+		"2006-01-02T15:04:05.999999999",
+	}
+
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, timeStr)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupport time format: %s", timeStr)
+}
+
+// sameEndDate reports whether the configured end date and the one returned by
+// FortiFlex describe the same time, e.g. "2025-12-31" and "2025-12-31T00:00:00".
+func sameEndDate(planned string, current string) bool {
+	if planned == current {
+		return true
+	}
+	planned_time, err := tryParseISO8601(planned)
+	if err != nil {
+		return false
+	}
+	current_time, err := tryParseISO8601(current)
+	if err != nil {
+		return false
+	}
+	return planned_time.Equal(current_time)
+}
+
+// sameFolderPath reports whether two folder paths are the same folder,
+// e.g. "My Assets/Branch" and "My Assets/Branch/".
+func sameFolderPath(a string, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// DEFAULT_CREATE_TIMEOUT is the create timeout of resources that wait for an entitlement status.
+const DEFAULT_CREATE_TIMEOUT = 30 * time.Minute
+
+// DEFAULT_POLL_INTERVAL is the first interval between two status requests.
+const DEFAULT_POLL_INTERVAL = 10 * time.Second
+
+// entitlementTimeoutsBlock is the 'timeouts' block of entitlement resources.
+func entitlementTimeoutsBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Attributes: map[string]schema.Attribute{
+			"create": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long to wait for wait_for_status when the entitlement is created, e.g. \"30m\". Default is 30m.",
+			},
+		},
+	}
+}
+
+type entitlementTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+}
+
+// createTimeout returns the create timeout of the 'timeouts' block, or default_timeout if it is not set.
+func createTimeout(timeouts *entitlementTimeoutsModel, default_timeout time.Duration) (time.Duration, error) {
+	if timeouts == nil || !isSet(timeouts.Create) {
+		return default_timeout, nil
+	}
+	timeout, err := time.ParseDuration(timeouts.Create.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeouts.create %q: %v", timeouts.Create.ValueString(), err)
+	}
+	return timeout, nil
+}
@@ -47,6 +47,17 @@ func NewClient(username string, password string) (*FortiSDKClient, error) {
 // generateToken() generate token from the Device
 // It returns the token
 func (client *FortiSDKClient) generateToken() error {
+	token, _, err := client.GenerateAccessToken()
+	if err != nil {
+		return err
+	}
+	client.Auth.Token = token
+	return nil
+}
+
+// GenerateAccessToken requests a new OAuth access token with the credentials of the client
+// It returns the token and its expiry time, the token used by the client is unchanged
+func (client *FortiSDKClient) GenerateAccessToken() (string, time.Time, error) {
 	var err error
 
 	data := map[string]string{
@@ -59,7 +70,7 @@ func (client *FortiSDKClient) generateToken() error {
 	dataJson, err := json.Marshal(data)
 	if err != nil {
 		err = fmt.Errorf("Could not login: %s", err)
-		return err
+		return "", time.Time{}, err
 	}
 
 	req := request.NewRequest(client.Auth, client.HTTPCon, "POST", "", nil, bytes.NewBuffer(dataJson))
@@ -67,28 +78,38 @@ func (client *FortiSDKClient) generateToken() error {
 	req.HTTPRequest.URL, err = url.Parse(u)
 	if err != nil {
 		err = fmt.Errorf("Could not parse URL: %s", err)
-		return err
+		return "", time.Time{}, err
 	}
 
+	requested_at := time.Now()
 	rsp, err := req.HTTPCon.Do(req.HTTPRequest)
+	if err != nil {
+		err = fmt.Errorf("Could not login: %s", err)
+		return "", time.Time{}, err
+	}
 	body, err := ioutil.ReadAll(rsp.Body)
 	rsp.Body.Close()
-	log.Printf("[INFO] FortiFlex login response: %s", string(body))
+	// The response contains the access token, only log the status
+	log.Printf("[INFO] FortiFlex login response: %s", rsp.Status)
 	if err != nil || body == nil {
 		err = fmt.Errorf("cannot get response body %v", err)
-		return err
+		return "", time.Time{}, err
 	}
 
 	var result map[string]interface{}
 	json.Unmarshal([]byte(string(body)), &result)
-	if result["access_token"] != nil && result["access_token"] != "" {
-		client.Auth.Token = result["access_token"].(string)
-	} else {
+	token, ok := result["access_token"].(string)
+	if !ok || token == "" {
 		err = fmt.Errorf("Can not get Token.")
-		return err
+		return "", time.Time{}, err
 	}
 
-	return nil
+	var expiry time.Time
+	if expires_in, ok := result["expires_in"].(float64); ok {
+		expiry = requested_at.Add(time.Duration(expires_in) * time.Second)
+	}
+
+	return token, expiry, nil
 }
 
 func fortiAPIErrorFormat(result map[string]interface{}, body string) (err error) {
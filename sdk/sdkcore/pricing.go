@@ -0,0 +1,226 @@
+package forticlient
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pricingTableJSON is the reference point rates of the configurations. The
+// rates of a contract may differ, FortiFlex doesn't publish them in the API.
+//
+//go:embed pricing_table.json
+var pricingTableJSON []byte
+
+// PricingRate is the daily points of one unit. If Per is set, the points are
+// multiplied by the value of the parameter Per, e.g. "cpu_size".
+type PricingRate struct {
+	Points float64 `json:"points"`
+	Per    string  `json:"per,omitempty"`
+}
+
+// ProductPricing is the daily points of one product type.
+// Units are multiplied by the numeric value of the parameter, Options are
+// added for each selected value of the parameter.
+type ProductPricing struct {
+	Base     float64                           `json:"base"`
+	Required []string                          `json:"required"`
+	Units    map[string]PricingRate            `json:"units"`
+	Options  map[string]map[string]PricingRate `json:"options"`
+}
+
+// PricingTable is a versioned table of the daily points of the product types.
+type PricingTable struct {
+	Version  string                    `json:"version"`
+	Products map[string]ProductPricing `json:"products"`
+}
+
+var embeddedPricing struct {
+	once  sync.Once
+	table *PricingTable
+	err   error
+}
+
+// EmbeddedPricingTable returns the pricing table built into the SDK.
+func EmbeddedPricingTable() (*PricingTable, error) {
+	embeddedPricing.once.Do(func() {
+		embeddedPricing.table, embeddedPricing.err = ParsePricingTable(pricingTableJSON)
+	})
+	return embeddedPricing.table, embeddedPricing.err
+}
+
+// ParsePricingTable parses a pricing table in the format of pricing_table.json.
+func ParsePricingTable(data []byte) (*PricingTable, error) {
+	table := &PricingTable{}
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, fmt.Errorf("invalid pricing table: %v", err)
+	}
+	if table.Version == "" {
+		return nil, fmt.Errorf("invalid pricing table: version is empty")
+	}
+	return table, nil
+}
+
+// EstimateDailyPoints returns the daily points of one entitlement of the product type.
+// parameters uses the argument names of fortiflexvm_config, e.g. "cpu_size". The
+// values can be strings, numbers or lists of strings.
+func (t *PricingTable) EstimateDailyPoints(product_type string, parameters map[string]interface{}) (float64, error) {
+	product, ok := t.Products[product_type]
+	if !ok {
+		return 0, fmt.Errorf("product type %v is not in the pricing table %v", product_type, t.Version)
+	}
+	for _, name := range product.Required {
+		if isEmptyParameter(parameters[name]) {
+			return 0, fmt.Errorf("%v is required to estimate the points of %v", name, product_type)
+		}
+	}
+
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	points := product.Base
+	for _, name := range names {
+		value := parameters[name]
+		if isEmptyParameter(value) {
+			continue
+		}
+		if rate, ok := product.Units[name]; ok {
+			number, err := parameterNumber(name, value)
+			if err != nil {
+				return 0, err
+			}
+			rate_points, err := rate.points(parameters)
+			if err != nil {
+				return 0, err
+			}
+			points += number * rate_points
+			continue
+		}
+		options, ok := product.Options[name]
+		if !ok {
+			// The parameter doesn't change the points, e.g. cpu_size is only used by "per"
+			continue
+		}
+		for _, option := range parameterValues(value) {
+			rate, ok := options[option]
+			if !ok {
+				return 0, fmt.Errorf("%v = %v of %v is not in the pricing table %v", name, option, product_type, t.Version)
+			}
+			rate_points, err := rate.points(parameters)
+			if err != nil {
+				return 0, err
+			}
+			points += rate_points
+		}
+	}
+	return points, nil
+}
+
+func (r PricingRate) points(parameters map[string]interface{}) (float64, error) {
+	if r.Per == "" {
+		return r.Points, nil
+	}
+	number, err := parameterNumber(r.Per, parameters[r.Per])
+	if err != nil {
+		return 0, err
+	}
+	return r.Points * number, nil
+}
+
+func isEmptyParameter(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case []string:
+		return len(value) == 0
+	}
+	return false
+}
+
+func parameterNumber(name string, v interface{}) (float64, error) {
+	switch value := v.(type) {
+	case int:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	case float64:
+		return value, nil
+	case string:
+		number, err := strconv.ParseFloat(value, 64)
+		if err == nil {
+			return number, nil
+		}
+	}
+	return 0, fmt.Errorf("%v should be a number, got: %v", name, v)
+}
+
+func parameterValues(v interface{}) []string {
+	switch value := v.(type) {
+	case []string:
+		return value
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		return values
+	case string:
+		return strings.Split(value, ",")
+	}
+	return []string{fmt.Sprintf("%v", v)}
+}
+
+// ReadAverageDailyPoints returns the average daily points of one entitlement of
+// the configuration in the last 'days' days, and the number of entitlements
+// that used points. It returns 0 entitlements if none of them used points.
+func (c *FortiSDKClient) ReadAverageDailyPoints(config_id int, account_id int, days int) (float64, int, error) {
+	if days <= 0 {
+		days = 7
+	}
+	end_date := time.Now().UTC().AddDate(0, 0, -1)
+	start_date := end_date.AddDate(0, 0, 1-days)
+
+	request_obj := make(map[string]interface{})
+	request_obj["configId"] = config_id
+	request_obj["startDate"] = start_date.Format("2006-01-02")
+	request_obj["endDate"] = end_date.Format("2006-01-02")
+	if account_id != 0 {
+		request_obj["accountId"] = account_id
+	}
+	o, err := c.ReadEntitlementsPoint(&request_obj)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	total := 0.0
+	count := 0
+	entitlements, _ := o["entitlements"].([]interface{})
+	for _, ent := range entitlements {
+		ent_map, ok := ent.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		points, _ := ent_map["points"].(float64)
+		if points <= 0 {
+			continue
+		}
+		total += points
+		count += 1
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return total / float64(count) / float64(days), count, nil
+}
@@ -0,0 +1,103 @@
+package forticlient
+
+import (
+	"math"
+	"testing"
+)
+
+const testPricingTableJSON = `{
+	"version": "test",
+	"products": {
+		"fgt_vm_bundle": {
+			"base": 1,
+			"required": ["cpu_size", "service_pkg"],
+			"units": {},
+			"options": {
+				"service_pkg": {
+					"FC": {"points": 0.5, "per": "cpu_size"},
+					"ATP": {"points": 0.25, "per": "cpu_size"}
+				},
+				"addons": {
+					"FGTAVDB": {"points": 0.1},
+					"FGTFAIS": {"points": 0.2, "per": "cpu_size"}
+				}
+			}
+		},
+		"fwbc_private": {
+			"base": 0,
+			"units": {
+				"average_throughput": {"points": 2},
+				"web_applications": {"points": 0.5}
+			}
+		}
+	}
+}`
+
+func TestEstimateDailyPoints(t *testing.T) {
+	table, err := ParsePricingTable([]byte(testPricingTableJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name         string
+		product_type string
+		parameters   map[string]interface{}
+		want         float64
+		err          bool
+	}{
+		{"option per cpu", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "4", "service_pkg": "FC"}, 3, false},
+		{"numeric cpu", "fgt_vm_bundle", map[string]interface{}{"cpu_size": 2, "service_pkg": "ATP"}, 1.5, false},
+		{"addons list", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2", "service_pkg": "FC", "addons": []interface{}{"FGTAVDB", "FGTFAIS"}}, 2.5, false},
+		{"addons string list", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2", "service_pkg": "FC", "addons": []string{"FGTAVDB"}}, 2.1, false},
+		{"addons comma separated", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2", "service_pkg": "FC", "addons": "FGTAVDB,FGTFAIS"}, 2.5, false},
+		{"empty addons", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2", "service_pkg": "FC", "addons": []interface{}{}}, 2, false},
+		{"unpriced parameter", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2", "service_pkg": "FC", "vdom_num": 10}, 2, false},
+		{"units", "fwbc_private", map[string]interface{}{"average_throughput": 25, "web_applications": "4"}, 52, false},
+		{"missing required", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2"}, 0, true},
+		{"empty required", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2", "service_pkg": ""}, 0, true},
+		{"unknown option", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "2", "service_pkg": "UTP"}, 0, true},
+		{"invalid per", "fgt_vm_bundle", map[string]interface{}{"cpu_size": "two", "service_pkg": "FC"}, 0, true},
+		{"invalid unit", "fwbc_private", map[string]interface{}{"average_throughput": "fast"}, 0, true},
+		{"unknown product type", "fmg_vm", map[string]interface{}{"managed_dev": 10}, 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := table.EstimateDailyPoints(c.product_type, c.parameters)
+			if (err != nil) != c.err {
+				t.Fatalf("EstimateDailyPoints(%v, %v) error = %v, want error %v", c.product_type, c.parameters, err, c.err)
+			}
+			if math.Abs(got-c.want) > 1e-9 {
+				t.Errorf("EstimateDailyPoints(%v, %v) = %v, want %v", c.product_type, c.parameters, got, c.want)
+			}
+		})
+	}
+}
+
+func TestParsePricingTable(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  bool
+	}{
+		{"valid", testPricingTableJSON, false},
+		{"no version", `{"products": {}}`, true},
+		{"invalid JSON", `{"version": "test",`, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := ParsePricingTable([]byte(c.data)); (err != nil) != c.err {
+				t.Errorf("ParsePricingTable() error = %v, want error %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestEmbeddedPricingTable(t *testing.T) {
+	table, err := EmbeddedPricingTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Products) == 0 {
+		t.Errorf("the embedded pricing table %v has no product", table.Version)
+	}
+}
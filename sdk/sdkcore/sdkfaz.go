@@ -20,6 +20,16 @@ func (c *FortiSDKClient) ReadProgramsList(params *map[string]interface{}) (mapTm
 	return
 }
 
+// ReadProgramsPoints API operation for FortiFlex gets the points balance of Programs
+// Returns the requested value when the request executes successfully.
+// Returns error for service API and SDK errors.
+func (c *FortiSDKClient) ReadProgramsPoints(params *map[string]interface{}) (mapTmp map[string]interface{}, err error) {
+	path := "/ES/api/fortiflex/v2/programs/points"
+	rspKey := "programs"
+	mapTmp, err = read(c, "POST", path, rspKey, params)
+	return
+}
+
 // ReadConfigsList API operation for FortiFlex gets the Configurations list
 // Returns the requested value when the request executes successfully.
 // Returns error for service API and SDK errors.
@@ -144,13 +154,8 @@ func (c *FortiSDKClient) UpdateVmUpdateRegenerateToken(params *map[string]interf
 // Returns the requested value when the request executes successfully.
 // Returns error for service API and SDK errors.
 func (c *FortiSDKClient) ReadGroupsList(params *map[string]interface{}) (mapTmp map[string]interface{}, err error) {
-	path := "/ES/api/flexvm/v1/groups/list"
+	path := "/ES/api/fortiflex/v2/groups/list"
 	rspKey := "groups"
-	if value, ok := (*params)["accountId"]; ok {
-		if value != 0 {
-			path = "/ES/api/fortiflex/v2/groups/list"
-		}
-	}
 	mapTmp, err = read(c, "POST", path, rspKey, params)
 	return
 }